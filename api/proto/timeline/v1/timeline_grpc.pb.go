@@ -0,0 +1,247 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: timeline/v1/timeline.proto
+
+package timelinev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TimelineService_GetTimeline_FullMethodName            = "/timeline.v1.TimelineService/GetTimeline"
+	TimelineService_AddPostToTimeline_FullMethodName      = "/timeline.v1.TimelineService/AddPostToTimeline"
+	TimelineService_RemovePostFromTimeline_FullMethodName = "/timeline.v1.TimelineService/RemovePostFromTimeline"
+	TimelineService_BackfillTimeline_FullMethodName       = "/timeline.v1.TimelineService/BackfillTimeline"
+)
+
+// TimelineServiceClient is the client API for TimelineService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TimelineService exposes the user timelines to the internal services.
+type TimelineServiceClient interface {
+	// GetTimeline returns the posts of the user timeline between two days, newest first.
+	GetTimeline(ctx context.Context, in *GetTimelineRequest, opts ...grpc.CallOption) (*GetTimelineResponse, error)
+	// AddPostToTimeline adds a post to the user timeline, adding it twice is a no-op.
+	AddPostToTimeline(ctx context.Context, in *AddPostToTimelineRequest, opts ...grpc.CallOption) (*AddPostToTimelineResponse, error)
+	// RemovePostFromTimeline removes a post from the user timeline.
+	RemovePostFromTimeline(ctx context.Context, in *RemovePostFromTimelineRequest, opts ...grpc.CallOption) (*RemovePostFromTimelineResponse, error)
+	// BackfillTimeline adds the recent posts of the followed user to the follower timeline.
+	BackfillTimeline(ctx context.Context, in *BackfillTimelineRequest, opts ...grpc.CallOption) (*BackfillTimelineResponse, error)
+}
+
+type timelineServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTimelineServiceClient(cc grpc.ClientConnInterface) TimelineServiceClient {
+	return &timelineServiceClient{cc}
+}
+
+func (c *timelineServiceClient) GetTimeline(ctx context.Context, in *GetTimelineRequest, opts ...grpc.CallOption) (*GetTimelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTimelineResponse)
+	err := c.cc.Invoke(ctx, TimelineService_GetTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timelineServiceClient) AddPostToTimeline(ctx context.Context, in *AddPostToTimelineRequest, opts ...grpc.CallOption) (*AddPostToTimelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddPostToTimelineResponse)
+	err := c.cc.Invoke(ctx, TimelineService_AddPostToTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timelineServiceClient) RemovePostFromTimeline(ctx context.Context, in *RemovePostFromTimelineRequest, opts ...grpc.CallOption) (*RemovePostFromTimelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemovePostFromTimelineResponse)
+	err := c.cc.Invoke(ctx, TimelineService_RemovePostFromTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *timelineServiceClient) BackfillTimeline(ctx context.Context, in *BackfillTimelineRequest, opts ...grpc.CallOption) (*BackfillTimelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BackfillTimelineResponse)
+	err := c.cc.Invoke(ctx, TimelineService_BackfillTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TimelineServiceServer is the server API for TimelineService service.
+// All implementations must embed UnimplementedTimelineServiceServer
+// for forward compatibility.
+//
+// TimelineService exposes the user timelines to the internal services.
+type TimelineServiceServer interface {
+	// GetTimeline returns the posts of the user timeline between two days, newest first.
+	GetTimeline(context.Context, *GetTimelineRequest) (*GetTimelineResponse, error)
+	// AddPostToTimeline adds a post to the user timeline, adding it twice is a no-op.
+	AddPostToTimeline(context.Context, *AddPostToTimelineRequest) (*AddPostToTimelineResponse, error)
+	// RemovePostFromTimeline removes a post from the user timeline.
+	RemovePostFromTimeline(context.Context, *RemovePostFromTimelineRequest) (*RemovePostFromTimelineResponse, error)
+	// BackfillTimeline adds the recent posts of the followed user to the follower timeline.
+	BackfillTimeline(context.Context, *BackfillTimelineRequest) (*BackfillTimelineResponse, error)
+	mustEmbedUnimplementedTimelineServiceServer()
+}
+
+// UnimplementedTimelineServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTimelineServiceServer struct{}
+
+func (UnimplementedTimelineServiceServer) GetTimeline(context.Context, *GetTimelineRequest) (*GetTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimeline not implemented")
+}
+func (UnimplementedTimelineServiceServer) AddPostToTimeline(context.Context, *AddPostToTimelineRequest) (*AddPostToTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPostToTimeline not implemented")
+}
+func (UnimplementedTimelineServiceServer) RemovePostFromTimeline(context.Context, *RemovePostFromTimelineRequest) (*RemovePostFromTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePostFromTimeline not implemented")
+}
+func (UnimplementedTimelineServiceServer) BackfillTimeline(context.Context, *BackfillTimelineRequest) (*BackfillTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackfillTimeline not implemented")
+}
+func (UnimplementedTimelineServiceServer) mustEmbedUnimplementedTimelineServiceServer() {}
+func (UnimplementedTimelineServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeTimelineServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TimelineServiceServer will
+// result in compilation errors.
+type UnsafeTimelineServiceServer interface {
+	mustEmbedUnimplementedTimelineServiceServer()
+}
+
+func RegisterTimelineServiceServer(s grpc.ServiceRegistrar, srv TimelineServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTimelineServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TimelineService_ServiceDesc, srv)
+}
+
+func _TimelineService_GetTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimelineServiceServer).GetTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimelineService_GetTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimelineServiceServer).GetTimeline(ctx, req.(*GetTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimelineService_AddPostToTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPostToTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimelineServiceServer).AddPostToTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimelineService_AddPostToTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimelineServiceServer).AddPostToTimeline(ctx, req.(*AddPostToTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimelineService_RemovePostFromTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePostFromTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimelineServiceServer).RemovePostFromTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimelineService_RemovePostFromTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimelineServiceServer).RemovePostFromTimeline(ctx, req.(*RemovePostFromTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TimelineService_BackfillTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackfillTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimelineServiceServer).BackfillTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TimelineService_BackfillTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimelineServiceServer).BackfillTimeline(ctx, req.(*BackfillTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TimelineService_ServiceDesc is the grpc.ServiceDesc for TimelineService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TimelineService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "timeline.v1.TimelineService",
+	HandlerType: (*TimelineServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTimeline",
+			Handler:    _TimelineService_GetTimeline_Handler,
+		},
+		{
+			MethodName: "AddPostToTimeline",
+			Handler:    _TimelineService_AddPostToTimeline_Handler,
+		},
+		{
+			MethodName: "RemovePostFromTimeline",
+			Handler:    _TimelineService_RemovePostFromTimeline_Handler,
+		},
+		{
+			MethodName: "BackfillTimeline",
+			Handler:    _TimelineService_BackfillTimeline_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "timeline/v1/timeline.proto",
+}
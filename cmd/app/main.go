@@ -5,11 +5,14 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/pkgerrors"
 	_ "go.uber.org/automaxprocs/maxprocs"
+	"net"
 	httpServer "net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata"
 	"uala-timeline-service/cmd/consumer"
+	"uala-timeline-service/cmd/grpc"
 	"uala-timeline-service/cmd/http"
 	"uala-timeline-service/config"
 )
@@ -51,10 +54,25 @@ func main() {
 		}
 	}()
 
+	grpcServer := grpc.SetupServer(cfg, dependencies)
+	go func() {
+		fmt.Println("starting grpc server on port: ", cfg.GRPC.Port)
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GRPC.Port))
+		if err != nil {
+			fmt.Println("error starting grpc server")
+			panic(err)
+		}
+		if err := grpcServer.Serve(listener); err != nil {
+			fmt.Println("error starting grpc server")
+			panic(err)
+		}
+	}()
+
 	exit := make(chan os.Signal, 1)
 	signal.Notify(exit, os.Interrupt, syscall.SIGTERM)
 
 	<-exit
+	grpcServer.GracefulStop()
 	c.Close()
 	for _, s := range subscriptions {
 		_ = s.Unsubscribe()
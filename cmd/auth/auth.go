@@ -0,0 +1,122 @@
+// Package auth authenticates the callers of the HTTP and gRPC APIs and authorizes them on
+// the timelines they reach.
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"uala-timeline-service/config"
+	"uala-timeline-service/internal/application"
+)
+
+const defaultUserHeader = "X-User-ID"
+
+var defaultServiceScopes = []string{"timeline:service", "timeline:admin"}
+
+var (
+	ErrUnauthenticated      = application.NewUnauthenticatedError("missing user credentials")
+	ErrInvalidToken         = application.NewUnauthenticatedError("invalid bearer token")
+	ErrTimelineNotOwned     = application.NewPermissionDeniedError("users can only access their own timeline")
+	ErrServiceScopeRequired = application.NewPermissionDeniedError("a service scope is required")
+	ErrNoAuthenticator      = errors.New("no jwt keys configured and trust_gateway_header is off")
+)
+
+// Principal is the caller of a request, Subject is the user ID.
+type Principal struct {
+	Subject string
+	Scopes  []string
+}
+
+func (p *Principal) HasAnyScope(scopes []string) bool {
+	for _, scope := range p.Scopes {
+		if slices.Contains(scopes, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticator resolves the caller from the request headers, the gRPC metadata is passed
+// as headers too.
+type Authenticator interface {
+	Authenticate(header http.Header) (*Principal, error)
+}
+
+// NewAuthenticator verifies bearer tokens when JWT keys are configured. The user header set
+// by the API gateway is only trusted with the explicit trust_gateway_header opt-in, as any
+// caller can set it.
+func NewAuthenticator(cfg config.Auth) (Authenticator, error) {
+	if cfg.JWT.Enabled() {
+		return newJWTAuthenticator(cfg.JWT)
+	}
+	if cfg.TrustGatewayHeader {
+		return newHeaderAuthenticator(cfg.UserHeader), nil
+	}
+	return nil, ErrNoAuthenticator
+}
+
+// headerAuthenticator trusts the user header set by the API gateway in front of the service.
+type headerAuthenticator struct {
+	header string
+}
+
+func newHeaderAuthenticator(header string) *headerAuthenticator {
+	if header == "" {
+		header = defaultUserHeader
+	}
+	return &headerAuthenticator{
+		header: header,
+	}
+}
+
+func (a *headerAuthenticator) Authenticate(header http.Header) (*Principal, error) {
+	userID := header.Get(a.header)
+	if userID == "" {
+		return nil, ErrUnauthenticated
+	}
+	return &Principal{Subject: userID}, nil
+}
+
+// Authorizer lets callers reach their own timeline, callers holding one of the service
+// scopes reach the timeline of any user and the internal endpoints.
+type Authorizer struct {
+	serviceScopes []string
+}
+
+func NewAuthorizer(serviceScopes []string) *Authorizer {
+	if len(serviceScopes) == 0 {
+		serviceScopes = defaultServiceScopes
+	}
+	return &Authorizer{
+		serviceScopes: serviceScopes,
+	}
+}
+
+func (a *Authorizer) AuthorizeTimeline(principal *Principal, userID string) error {
+	if principal.Subject != userID && !principal.HasAnyScope(a.serviceScopes) {
+		return ErrTimelineNotOwned
+	}
+	return nil
+}
+
+func (a *Authorizer) AuthorizeService(principal *Principal) error {
+	if !principal.HasAnyScope(a.serviceScopes) {
+		return ErrServiceScopeRequired
+	}
+	return nil
+}
+
+type principalKey struct{}
+
+// NewContext stores the authenticated caller on the context.
+func NewContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller stored by NewContext.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+	"uala-timeline-service/config"
+)
+
+const jwtLeeway = 30 * time.Second
+
+var ErrUnknownSigningKey = errors.New("unknown signing key")
+
+// jwtAuthenticator verifies HS256 and RS256 bearer tokens. Keys are looked up by the kid
+// header, static keys are stored without kid and are used when the kid is unknown.
+type jwtAuthenticator struct {
+	parser   *jwt.Parser
+	hmacKeys map[string][]byte
+	rsaKeys  map[string]*rsa.PublicKey
+}
+
+// TimelineClaims carries the OAuth2 scopes as a space separated list.
+type TimelineClaims struct {
+	Scope string `json:"scope,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func newJWTAuthenticator(cfg config.JWT) (*jwtAuthenticator, error) {
+	a := &jwtAuthenticator{
+		hmacKeys: map[string][]byte{},
+		rsaKeys:  map[string]*rsa.PublicKey{},
+	}
+
+	if cfg.HMACSecret != "" {
+		a.hmacKeys[""] = []byte(cfg.HMACSecret)
+	}
+	if cfg.PublicKeyFile != "" {
+		pemKey, err := os.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading jwt public key: %w", err)
+		}
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(pemKey)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing jwt public key: %w", err)
+		}
+		a.rsaKeys[""] = publicKey
+	}
+	if cfg.JWKSFile != "" {
+		if err := a.loadJWKS(cfg.JWKSFile); err != nil {
+			return nil, err
+		}
+	}
+
+	var methods []string
+	if len(a.hmacKeys) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if len(a.rsaKeys) > 0 {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("no jwt keys configured")
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(jwtLeeway),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+	a.parser = jwt.NewParser(options...)
+
+	return a, nil
+}
+
+func (a *jwtAuthenticator) Authenticate(header http.Header) (*Principal, error) {
+	scheme, rawToken, found := strings.Cut(header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || rawToken == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	var claims TimelineClaims
+	if _, err := a.parser.ParseWithClaims(rawToken, &claims, a.key); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return &Principal{
+		Subject: claims.Subject,
+		Scopes:  strings.Fields(claims.Scope),
+	}, nil
+}
+
+// key only returns keys of the token algorithm family so an RSA public key is never used
+// as an HMAC secret.
+func (a *jwtAuthenticator) key(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if key, ok := a.hmacKeys[kid]; ok {
+			return key, nil
+		}
+		if key, ok := a.hmacKeys[""]; ok {
+			return key, nil
+		}
+	case *jwt.SigningMethodRSA:
+		if key, ok := a.rsaKeys[kid]; ok {
+			return key, nil
+		}
+		if key, ok := a.rsaKeys[""]; ok {
+			return key, nil
+		}
+	}
+	return nil, ErrUnknownSigningKey
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+// loadJWKS reads the RSA and oct signing keys of a JWKS file, other keys are skipped.
+func (a *jwtAuthenticator) loadJWKS(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading jwks file: %w", err)
+	}
+
+	var keySet jsonWebKeySet
+	if err := json.Unmarshal(data, &keySet); err != nil {
+		return fmt.Errorf("error parsing jwks file: %w", err)
+	}
+
+	for _, key := range keySet.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		switch key.Kty {
+		case "RSA":
+			publicKey, err := key.rsaPublicKey()
+			if err != nil {
+				return fmt.Errorf("error parsing jwks key %q: %w", key.Kid, err)
+			}
+			a.rsaKeys[key.Kid] = publicKey
+		case "oct":
+			secret, err := base64.RawURLEncoding.DecodeString(key.K)
+			if err != nil {
+				return fmt.Errorf("error parsing jwks key %q: %w", key.Kid, err)
+			}
+			a.hmacKeys[key.Kid] = secret
+		}
+	}
+
+	return nil
+}
+
+func (k jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid rsa exponent")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}, nil
+}
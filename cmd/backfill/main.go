@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+	"uala-timeline-service/config"
+	"uala-timeline-service/internal/application"
+)
+
+func main() {
+	followerID := flag.String("follower", "", "ID of the user whose timeline will be backfilled")
+	followedID := flag.String("followed", "", "ID of the author whose posts will be added")
+	lookbackDays := flag.Int("lookback_days", 0, "days to look back, defaults to the configured value")
+	authors := flag.Bool("authors", false, "set the author of the timeline rows stored without one instead")
+	flag.Parse()
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		panic(fmt.Errorf("fatal error loading config file: %w", err))
+	}
+	if *lookbackDays > 0 {
+		cfg.Backfill.LookbackDays = *lookbackDays
+	}
+
+	dependencies, err := config.BuildDependencies(*cfg)
+	if err != nil {
+		panic(fmt.Errorf("fatal error building dependencies: %w", err))
+	}
+
+	if *authors {
+		backfillAuthors(dependencies)
+		return
+	}
+
+	backfillUserTimeline := application.NewBackfillUserTimeline(
+		dependencies.PostRepository,
+		dependencies.TimelineService,
+		cfg.Backfill.Lookback(),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	err = backfillUserTimeline.Exec(ctx, &application.BackfillUserTimelineCommand{
+		FollowerID: *followerID,
+		FollowedID: *followedID,
+	})
+	if err != nil {
+		fmt.Println("error backfilling timeline: ", err)
+		os.Exit(1)
+	}
+	fmt.Println("timeline backfilled for user: ", *followerID)
+}
+
+// backfillAuthors runs until every row without author is read, it may take long on big
+// tables and can be run again after it is stopped.
+func backfillAuthors(dependencies *config.Dependencies) {
+	backfillTimelineAuthors := application.NewBackfillTimelineAuthors(
+		dependencies.TimelineRepository,
+		dependencies.PostRepository,
+		0,
+	)
+
+	result, err := backfillTimelineAuthors.Exec(context.Background())
+	if err != nil {
+		fmt.Println("error backfilling timeline authors: ", err)
+		os.Exit(1)
+	}
+	fmt.Printf("timeline authors backfilled, %d posts updated, %d deleted posts skipped\n", result.Updated, result.Skipped)
+}
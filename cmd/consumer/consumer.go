@@ -5,6 +5,7 @@ import (
 	"github.com/nats-io/nats.go"
 	"log"
 	"uala-timeline-service/config"
+	"uala-timeline-service/internal/domain/post_stats"
 )
 
 func SetupConsumer(config *config.Config, deps *config.Dependencies) (*nats.Conn, []*nats.Subscription) {
@@ -13,11 +14,11 @@ func SetupConsumer(config *config.Config, deps *config.Dependencies) (*nats.Conn
 		log.Fatal("Error conectando a NATS:", err)
 	}
 
-	qsub, err := nc.QueueSubscribe("post.created", config.ServiceName, handlePostCreated(deps))
+	qsub, err := nc.QueueSubscribe("post.created", config.ServiceName, handlePostCreated(config, deps))
 	if err != nil {
 		log.Fatalf("Error en QueueSubscribe: %v", err)
 	}
-	qsub2, err := nc.QueueSubscribe("user_timeline.add_post", config.ServiceName, addPostToTimeline(deps))
+	qsub2, err := nc.QueueSubscribe("user_timeline.add_post", config.ServiceName, addPostToTimeline(config, deps))
 	if err != nil {
 		log.Fatalf("Error en QueueSubscribe: %v", err)
 	}
@@ -25,6 +26,50 @@ func SetupConsumer(config *config.Config, deps *config.Dependencies) (*nats.Conn
 	if err != nil {
 		log.Fatalf("Error en QueueSubscribe: %v", err)
 	}
-	subscriptions := []*nats.Subscription{qsub, qsub2, qsub3}
+	qsub4, err := nc.QueueSubscribe("follow.created", config.ServiceName, handleFollowCreated(config, deps))
+	if err != nil {
+		log.Fatalf("Error en QueueSubscribe: %v", err)
+	}
+	qsub5, err := nc.QueueSubscribe("follow.deleted", config.ServiceName, handleFollowDeleted(deps))
+	if err != nil {
+		log.Fatalf("Error en QueueSubscribe: %v", err)
+	}
+	qsub6, err := nc.QueueSubscribe("post.updated", config.ServiceName, handlePostUpdated(config, deps))
+	if err != nil {
+		log.Fatalf("Error en QueueSubscribe: %v", err)
+	}
+	qsub7, err := nc.QueueSubscribe("user_timeline.update_post", config.ServiceName, updatePostInTimeline(deps))
+	if err != nil {
+		log.Fatalf("Error en QueueSubscribe: %v", err)
+	}
+	qsub8, err := nc.QueueSubscribe("post.deleted", config.ServiceName, handlePostDeleted(config, deps))
+	if err != nil {
+		log.Fatalf("Error en QueueSubscribe: %v", err)
+	}
+	subscriptions := []*nats.Subscription{qsub, qsub2, qsub3, qsub4, qsub5, qsub6, qsub7, qsub8}
+
+	engagements := map[string]string{
+		"post.liked":    post_stats.EngagementLike,
+		"post.reposted": post_stats.EngagementRepost,
+		"post.replied":  post_stats.EngagementReply,
+	}
+	for subject, kind := range engagements {
+		sub, err := nc.QueueSubscribe(subject, config.ServiceName, recordPostEngagement(deps, kind))
+		if err != nil {
+			log.Fatalf("Error en QueueSubscribe: %v", err)
+		}
+		subscriptions = append(subscriptions, sub)
+	}
+
+	// Every replica invalidates its own post cache, so these are not queue subscriptions
+	if deps.PostCache != nil {
+		for _, subject := range []string{"post.updated", "post.deleted"} {
+			sub, err := nc.Subscribe(subject, invalidatePostCache(deps))
+			if err != nil {
+				log.Fatalf("Error en Subscribe: %v", err)
+			}
+			subscriptions = append(subscriptions, sub)
+		}
+	}
 	return nc, subscriptions
 }
@@ -3,18 +3,18 @@ package consumer
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"github.com/nats-io/nats.go"
 	"github.com/rs/zerolog/log"
 	"uala-timeline-service/config"
 	"uala-timeline-service/internal/application"
 )
 
-func handlePostCreated(dependencies *config.Dependencies) func(msg *nats.Msg) {
+func handlePostCreated(cfg *config.Config, dependencies *config.Dependencies) func(msg *nats.Msg) {
 	splitPostUpdateForUsers := application.NewSplitPostUpdateForUsers(
 		dependencies.PostRepository,
 		dependencies.FollowRepository,
 		dependencies.EventPublisher,
+		cfg.Fanout.IncludeAuthor,
 	)
 	return func(msg *nats.Msg) {
 		log.Info().Msg("handlePostCreated event")
@@ -27,13 +27,62 @@ func handlePostCreated(dependencies *config.Dependencies) func(msg *nats.Msg) {
 		err = splitPostUpdateForUsers.Exec(context.Background(), &cmd)
 		if err != nil {
 			msg.Nak()
+			return
 		}
 		msg.Ack()
 	}
 }
 
-func addPostToTimeline(dependencies *config.Dependencies) func(msg *nats.Msg) {
-	addPostToTimeline := application.NewAddPostToUserTimeline(dependencies.TimelineService)
+func handlePostUpdated(cfg *config.Config, dependencies *config.Dependencies) func(msg *nats.Msg) {
+	splitPostEditForUsers := application.NewSplitPostEditForUsers(
+		dependencies.PostRepository,
+		dependencies.FollowRepository,
+		dependencies.EventPublisher,
+		cfg.Fanout.IncludeAuthor,
+	)
+	return func(msg *nats.Msg) {
+		log.Info().Msg("handlePostUpdated event")
+		var cmd application.SplitPostUpdateForUsersCommand
+		err := json.Unmarshal(msg.Data, &cmd)
+		if err != nil {
+			log.Err(err)
+			return
+		}
+		err = splitPostEditForUsers.Exec(context.Background(), &cmd)
+		if err != nil {
+			msg.Nak()
+			return
+		}
+		msg.Ack()
+	}
+}
+
+func handlePostDeleted(cfg *config.Config, dependencies *config.Dependencies) func(msg *nats.Msg) {
+	splitPostDeleteForUsers := application.NewSplitPostDeleteForUsers(
+		dependencies.PostRepository,
+		dependencies.FollowRepository,
+		dependencies.EventPublisher,
+		cfg.Fanout.IncludeAuthor,
+	)
+	return func(msg *nats.Msg) {
+		log.Info().Msg("handlePostDeleted event")
+		var cmd application.SplitPostUpdateForUsersCommand
+		err := json.Unmarshal(msg.Data, &cmd)
+		if err != nil {
+			log.Err(err)
+			return
+		}
+		err = splitPostDeleteForUsers.Exec(context.Background(), &cmd)
+		if err != nil {
+			msg.Nak()
+			return
+		}
+		msg.Ack()
+	}
+}
+
+func addPostToTimeline(cfg *config.Config, dependencies *config.Dependencies) func(msg *nats.Msg) {
+	addPostToTimeline := application.NewAddPostToUserTimeline(dependencies.TimelineService, dependencies.EventPublisher, cfg.Fanout.PayloadMaxAge())
 	return func(msg *nats.Msg) {
 		log.Info().Msg("addPostToTimeline event")
 		var cmd application.AddPostToUserTimelineCommand
@@ -45,14 +94,130 @@ func addPostToTimeline(dependencies *config.Dependencies) func(msg *nats.Msg) {
 		err = addPostToTimeline.Exec(context.Background(), &cmd)
 		if err != nil {
 			msg.Nak()
+			return
+		}
+		msg.Ack()
+	}
+}
+
+func handleFollowCreated(cfg *config.Config, dependencies *config.Dependencies) func(msg *nats.Msg) {
+	backfillUserTimeline := application.NewBackfillUserTimeline(
+		dependencies.PostRepository,
+		dependencies.TimelineService,
+		cfg.Backfill.Lookback(),
+	)
+	return func(msg *nats.Msg) {
+		log.Info().Msg("handleFollowCreated event")
+		var cmd application.BackfillUserTimelineCommand
+		err := json.Unmarshal(msg.Data, &cmd)
+		if err != nil {
+			log.Err(err)
+			return
+		}
+		err = backfillUserTimeline.Exec(context.Background(), &cmd)
+		if err != nil {
+			msg.Nak()
+			return
+		}
+		msg.Ack()
+	}
+}
+
+func handleFollowDeleted(dependencies *config.Dependencies) func(msg *nats.Msg) {
+	removeAuthorFromUserTimeline := application.NewRemoveAuthorFromUserTimeline(dependencies.TimelineService)
+	return func(msg *nats.Msg) {
+		log.Info().Msg("handleFollowDeleted event")
+		var cmd application.RemoveAuthorFromUserTimelineCommand
+		err := json.Unmarshal(msg.Data, &cmd)
+		if err != nil {
+			log.Err(err)
+			return
+		}
+		err = removeAuthorFromUserTimeline.Exec(context.Background(), &cmd)
+		if err != nil {
+			msg.Nak()
+			return
+		}
+		msg.Ack()
+	}
+}
+
+func updatePostInTimeline(dependencies *config.Dependencies) func(msg *nats.Msg) {
+	updatePostInUserTimeline := application.NewUpdatePostInUserTimeline(dependencies.TimelineService, dependencies.EventPublisher)
+	return func(msg *nats.Msg) {
+		log.Info().Msg("updatePostInTimeline event")
+		var cmd application.UpdatePostInUserTimelineCommand
+		err := json.Unmarshal(msg.Data, &cmd)
+		if err != nil {
+			log.Err(err)
+			return
+		}
+		err = updatePostInUserTimeline.Exec(context.Background(), &cmd)
+		if err != nil {
+			msg.Nak()
+			return
 		}
 		msg.Ack()
 	}
 }
 
 func removePostFromTimeline(dependencies *config.Dependencies) func(msg *nats.Msg) {
+	removePostToUserTimeline := application.NewRemovePostToUserTimelineTime(dependencies.TimelineService, dependencies.EventPublisher)
+	return func(msg *nats.Msg) {
+		log.Info().Msg("removePostFromTimeline event")
+		var cmd application.RemovePostToUserTimelineTimeCommand
+		err := json.Unmarshal(msg.Data, &cmd)
+		if err != nil {
+			log.Err(err)
+			return
+		}
+		err = removePostToUserTimeline.Exec(context.Background(), &cmd)
+		if err != nil {
+			msg.Nak()
+			return
+		}
+		msg.Ack()
+	}
+}
+
+// recordPostEngagement logs and drops the malformed and invalid events, they can not be
+// recorded.
+func recordPostEngagement(dependencies *config.Dependencies, kind string) func(msg *nats.Msg) {
+	recordPostEngagement := application.NewRecordPostEngagement(dependencies.PostStatsRepository, kind)
+	return func(msg *nats.Msg) {
+		log.Info().Str("kind", kind).Msg("recordPostEngagement event")
+		var cmd application.RecordPostEngagementCommand
+		err := json.Unmarshal(msg.Data, &cmd)
+		if err != nil {
+			log.Err(err).Str("kind", kind).Msg("dropping malformed post engagement event")
+			msg.Ack()
+			return
+		}
+		err = recordPostEngagement.Exec(context.Background(), &cmd)
+		if application.IsValidationError(err) {
+			log.Err(err).Str("kind", kind).Str("post_id", cmd.PostID).Msg("dropping invalid post engagement event")
+			msg.Ack()
+			return
+		}
+		if err != nil {
+			msg.Nak()
+			return
+		}
+		msg.Ack()
+	}
+}
+
+func invalidatePostCache(dependencies *config.Dependencies) func(msg *nats.Msg) {
 	return func(msg *nats.Msg) {
-		//TODO implement me
-		fmt.Println("removePostFromTimeline")
+		var cmd application.SplitPostUpdateForUsersCommand
+		err := json.Unmarshal(msg.Data, &cmd)
+		if err != nil {
+			log.Err(err)
+			return
+		}
+		err = dependencies.PostCache.Invalidate(context.Background(), cmd.ID)
+		if err != nil {
+			log.Err(err).Str("post_id", cmd.ID).Msg("error invalidating cached post")
+		}
 	}
 }
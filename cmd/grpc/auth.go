@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"net/http"
+	"strings"
+	timelinev1 "uala-timeline-service/api/proto/timeline/v1"
+	"uala-timeline-service/cmd/auth"
+)
+
+var timelineMethodPrefix = "/" + timelinev1.TimelineService_ServiceDesc.ServiceName + "/"
+
+// userMethods are the calls open to end users, on their own timeline. The other timeline
+// calls change timelines on behalf of the internal services and need a service scope.
+var userMethods = map[string]bool{
+	timelinev1.TimelineService_GetTimeline_FullMethodName: true,
+}
+
+// authorizeTimelineCalls is the gRPC counterpart of the HTTP auth middlewares. It
+// authenticates the callers of the timeline service with the bearer token of the
+// authorization metadata, lets end users read only their own timeline and leaves the rest
+// of the calls to the callers holding one of the service scopes. Health checks are left
+// open.
+func authorizeTimelineCalls(authenticator auth.Authenticator, authorizer *auth.Authorizer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !strings.HasPrefix(info.FullMethod, timelineMethodPrefix) {
+			return handler(ctx, req)
+		}
+
+		principal, err := authenticator.Authenticate(metadataHeader(ctx))
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+		if err := authorizeCall(authorizer, principal, info.FullMethod, req); err != nil {
+			return nil, toStatusError(err)
+		}
+
+		return handler(auth.NewContext(ctx, principal), req)
+	}
+}
+
+func authorizeCall(authorizer *auth.Authorizer, principal *auth.Principal, method string, req interface{}) error {
+	if !userMethods[method] {
+		return authorizer.AuthorizeService(principal)
+	}
+	// Requests without the user are rejected by the handlers validation
+	r, ok := req.(interface{ GetUserId() string })
+	if !ok || r.GetUserId() == "" {
+		return nil
+	}
+	return authorizer.AuthorizeTimeline(principal, r.GetUserId())
+}
+
+// metadataHeader copies the incoming metadata to headers, the keys are canonicalized so
+// the authenticators read them as HTTP headers.
+func metadataHeader(ctx context.Context) http.Header {
+	header := http.Header{}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for key, values := range md {
+		for _, value := range values {
+			header.Add(key, value)
+		}
+	}
+	return header
+}
@@ -0,0 +1,199 @@
+package grpc
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	timelinev1 "uala-timeline-service/api/proto/timeline/v1"
+	"uala-timeline-service/config"
+	"uala-timeline-service/internal/application"
+)
+
+var (
+	ErrInvalidUser = application.NewValidationError("invalid user", nil, application.FieldViolation{Field: "user_id", Message: "is required"})
+	ErrInvalidPost = application.NewValidationError(
+		"user and post fields are mandatory",
+		nil,
+		application.FieldViolation{Field: "user_id", Message: "is required"},
+		application.FieldViolation{Field: "post_id", Message: "is required"},
+	)
+)
+
+type timelineServer struct {
+	timelinev1.UnimplementedTimelineServiceServer
+	getUserTimeline      *application.GetUserTimeline
+	addPostToTimeline    *application.AddPostToUserTimeline
+	removePostOfTimeline *application.RemovePostToUserTimelineTime
+	backfillTimeline     *application.BackfillUserTimeline
+}
+
+func newTimelineServer(cfg *config.Config, deps *config.Dependencies) *timelineServer {
+	return &timelineServer{
+		getUserTimeline:      application.NewGetUserTimeline(deps.TimelineService, deps.ReadMarkerRepository, deps.UserProfileRepository, deps.PostStatsRepository, deps.Rankers),
+		addPostToTimeline:    application.NewAddPostToUserTimeline(deps.TimelineService, deps.EventPublisher, cfg.Fanout.PayloadMaxAge()),
+		removePostOfTimeline: application.NewRemovePostToUserTimelineTime(deps.TimelineService, deps.EventPublisher),
+		backfillTimeline:     application.NewBackfillUserTimeline(deps.PostRepository, deps.TimelineService, cfg.Backfill.Lookback()),
+	}
+}
+
+func (s *timelineServer) GetTimeline(ctx context.Context, req *timelinev1.GetTimelineRequest) (*timelinev1.GetTimelineResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, toStatusError(ErrInvalidUser)
+	}
+
+	response, err := s.getUserTimeline.Exec(ctx, &application.GetUserTimelineCommand{
+		UserID:    req.GetUserId(),
+		FromDay:   int(req.GetFrom().GetDay()),
+		FromMonth: int(req.GetFrom().GetMonth()),
+		FromYear:  int(req.GetFrom().GetYear()),
+		ToDay:     int(req.GetTo().GetDay()),
+		ToMonth:   int(req.GetTo().GetMonth()),
+		ToYear:    int(req.GetTo().GetYear()),
+		Timezone:  req.GetTimezone(),
+		Limit:     int(req.GetLimit()),
+		Cursor:    req.GetCursor(),
+		DeviceID:  req.GetDeviceId(),
+		Expand:    req.GetExpand(),
+		Sort:      req.GetSort(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toGetTimelineResponse(response), nil
+}
+
+func (s *timelineServer) AddPostToTimeline(ctx context.Context, req *timelinev1.AddPostToTimelineRequest) (*timelinev1.AddPostToTimelineResponse, error) {
+	if req.GetUserId() == "" || req.GetPostId() == "" {
+		return nil, toStatusError(ErrInvalidPost)
+	}
+
+	err := s.addPostToTimeline.Exec(ctx, &application.AddPostToUserTimelineCommand{
+		UserID: req.GetUserId(),
+		PostID: req.GetPostId(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &timelinev1.AddPostToTimelineResponse{}, nil
+}
+
+func (s *timelineServer) RemovePostFromTimeline(ctx context.Context, req *timelinev1.RemovePostFromTimelineRequest) (*timelinev1.RemovePostFromTimelineResponse, error) {
+	if req.GetUserId() == "" || req.GetPostId() == "" {
+		return nil, toStatusError(ErrInvalidPost)
+	}
+
+	err := s.removePostOfTimeline.Exec(ctx, &application.RemovePostToUserTimelineTimeCommand{
+		UserID: req.GetUserId(),
+		PostID: req.GetPostId(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &timelinev1.RemovePostFromTimelineResponse{}, nil
+}
+
+func (s *timelineServer) BackfillTimeline(ctx context.Context, req *timelinev1.BackfillTimelineRequest) (*timelinev1.BackfillTimelineResponse, error) {
+	err := s.backfillTimeline.Exec(ctx, &application.BackfillUserTimelineCommand{
+		FollowerID: req.GetFollowerId(),
+		FollowedID: req.GetFollowedId(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &timelinev1.BackfillTimelineResponse{}, nil
+}
+
+func toContent(content application.Content) *timelinev1.Content {
+	message := &timelinev1.Content{
+		Type:            content.Type,
+		Text:            content.Text,
+		Url:             content.Url,
+		MentionedUserId: content.MentionedUserID,
+	}
+	if content.Data != nil {
+		data := string(content.Data)
+		message.Data = &data
+	}
+	if content.Media != nil {
+		message.Media = &timelinev1.Media{
+			MimeType:     content.Media.MimeType,
+			Width:        int32(content.Media.Width),
+			Height:       int32(content.Media.Height),
+			DurationMs:   int64(content.Media.DurationMs),
+			ThumbnailUrl: content.Media.ThumbnailUrl,
+			AltText:      content.Media.AltText,
+		}
+	}
+	if content.Preview != nil {
+		message.Preview = &timelinev1.LinkPreview{
+			Title:       content.Preview.Title,
+			Description: content.Preview.Description,
+			ImageUrl:    content.Preview.ImageUrl,
+			SiteName:    content.Preview.SiteName,
+		}
+	}
+	return message
+}
+
+func toGetTimelineResponse(response *application.GetUserTimelineResponse) *timelinev1.GetTimelineResponse {
+	timelinePosts := make([]*timelinev1.Post, len(response.Posts))
+	for i, post := range response.Posts {
+		contents := make([]*timelinev1.Content, len(post.Contents))
+		for j, content := range post.Contents {
+			contents[j] = toContent(content)
+		}
+		timelinePosts[i] = &timelinev1.Post{
+			Id:          post.ID,
+			AuthorId:    post.AuthorID,
+			Contents:    contents,
+			Seen:        post.Seen != nil && *post.Seen,
+			PublishedAt: timestamppb.New(post.PublishedAt),
+			UpdatedAt:   timestamppb.New(post.UpdatedAt),
+			Edited:      post.Edited,
+			Author:      toAuthor(post.Author),
+			Stats:       toPostStats(post.Stats),
+		}
+	}
+
+	return &timelinev1.GetTimelineResponse{
+		UserId:      response.UserID,
+		LastUpdate:  timestamppb.New(response.LastUpdate),
+		Posts:       timelinePosts,
+		NextCursor:  response.NextCursor,
+		UnreadCount: int32(response.UnreadCount),
+		Meta: &timelinev1.TimelineMeta{
+			From:     timestamppb.New(response.Meta.From),
+			To:       timestamppb.New(response.Meta.To),
+			Timezone: response.Meta.Timezone,
+			Source:   response.Meta.Source,
+			Sort:     response.Meta.Sort,
+		},
+	}
+}
+
+func toAuthor(author *application.Author) *timelinev1.Author {
+	if author == nil {
+		return nil
+	}
+	return &timelinev1.Author{
+		Id:          author.ID,
+		Username:    author.Username,
+		DisplayName: author.DisplayName,
+		AvatarUrl:   author.AvatarUrl,
+		Verified:    author.Verified,
+	}
+}
+
+func toPostStats(stats *application.PostStats) *timelinev1.PostStats {
+	if stats == nil {
+		return nil
+	}
+	return &timelinev1.PostStats{
+		Likes:   int64(stats.Likes),
+		Reposts: int64(stats.Reposts),
+		Replies: int64(stats.Replies),
+	}
+}
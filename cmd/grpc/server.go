@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	grpctrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/google.golang.org/grpc"
+	timelinev1 "uala-timeline-service/api/proto/timeline/v1"
+	"uala-timeline-service/cmd/auth"
+	"uala-timeline-service/config"
+)
+
+// SetupServer registers the timeline service, the health service and server reflection.
+// It shares the dependencies, use cases and auth of the HTTP API.
+func SetupServer(config *config.Config, deps *config.Dependencies) *grpc.Server {
+	authenticator, err := auth.NewAuthenticator(config.Auth)
+	if err != nil {
+		panic(fmt.Errorf("fatal error building authenticator: %w", err))
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			grpctrace.UnaryServerInterceptor(grpctrace.WithServiceName(config.ServiceName)),
+			authorizeTimelineCalls(authenticator, auth.NewAuthorizer(config.Auth.ServiceScopes)),
+		),
+	)
+
+	timelinev1.RegisterTimelineServiceServer(server, newTimelineServer(config, deps))
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(timelinev1.TimelineService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	reflection.Register(server)
+
+	return server
+}
@@ -0,0 +1,353 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"testing"
+	"time"
+	timelinev1 "uala-timeline-service/api/proto/timeline/v1"
+	"uala-timeline-service/cmd/auth"
+	"uala-timeline-service/config"
+	"uala-timeline-service/internal/domain/day_timeline_filled"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/internal/domain/timeline"
+	"uala-timeline-service/internal/infrastructure"
+	"uala-timeline-service/libs/events"
+	"uala-timeline-service/mocks"
+)
+
+const testHMACSecret = "test-secret"
+
+func setupClient(t *testing.T, deps *config.Dependencies) *grpc.ClientConn {
+	return setupClientWithAuth(t, config.Auth{TrustGatewayHeader: true}, deps)
+}
+
+func setupClientWithAuth(t *testing.T, authConfig config.Auth, deps *config.Dependencies) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	server := SetupServer(&config.Config{
+		ServiceName: "timeline-service",
+		Backfill:    config.Backfill{LookbackDays: 7},
+		Auth:        authConfig,
+	}, deps)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+// asUser authenticates the calls with the gateway user header.
+func asUser(userID string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-user-id", userID)
+}
+
+func withBearerToken(t *testing.T, subject string, scope string) context.Context {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.TimelineClaims{
+		Scope: scope,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}).SignedString([]byte(testHMACSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestGetTimeline(t *testing.T) {
+	now := time.Date(2025, 5, 21, 12, 0, 0, 0, time.UTC)
+	text := "hello"
+
+	tests := []struct {
+		name               string
+		request            *timelinev1.GetTimelineRequest
+		setupMocks         func(mockTimelineService *mocks.DayUserTimelineFilledService)
+		expectedCode       codes.Code
+		expectedPostIDs    []string
+		expectedViolations []string
+	}{
+		{
+			name: "should return timeline",
+			request: &timelinev1.GetTimelineRequest{
+				UserId: "user-456",
+				From:   &timelinev1.Date{Year: 2025, Month: 5, Day: 21},
+				To:     &timelinev1.Date{Year: 2025, Month: 5, Day: 21},
+			},
+			setupMocks: func(mockTimelineService *mocks.DayUserTimelineFilledService) {
+				mockTimelineService.On("GetDayUserTimelineFilled", mock.Anything, mock.MatchedBy(func(f day_timeline_filled.DayUserTimelineFilledFilter) bool {
+					return f.UserID == "user-456" && f.FromDay == 21 && f.ToDay == 21 && f.FromMonth == 5 && f.ToMonth == 5
+				})).Return(&day_timeline_filled.DayUserTimelineFilled{
+					UserID: "user-456",
+					Posts: []posts.Post{
+						{ID: "post-1", AuthorID: "author-789", PublishedAt: now.Add(-time.Hour), Contents: []posts.Content{{Type: "text", Text: &text}}},
+						{ID: "post-2", AuthorID: "author-789", PublishedAt: now},
+					},
+				}, nil).Once()
+			},
+			expectedCode:    codes.OK,
+			expectedPostIDs: []string{"post-2", "post-1"},
+		},
+		{
+			name: "should reject invalid ranges with the field violations",
+			request: &timelinev1.GetTimelineRequest{
+				UserId: "user-456",
+				From:   &timelinev1.Date{Year: 2025, Month: 5, Day: 28},
+				To:     &timelinev1.Date{Year: 2025, Month: 5, Day: 21},
+			},
+			expectedCode:       codes.InvalidArgument,
+			expectedViolations: []string{"to"},
+		},
+		{
+			name:               "should require the user",
+			request:            &timelinev1.GetTimelineRequest{},
+			expectedCode:       codes.InvalidArgument,
+			expectedViolations: []string{"user_id"},
+		},
+		{
+			name: "should return not found",
+			request: &timelinev1.GetTimelineRequest{
+				UserId: "user-456",
+				From:   &timelinev1.Date{Year: 2025, Month: 5, Day: 21},
+				To:     &timelinev1.Date{Year: 2025, Month: 5, Day: 21},
+			},
+			setupMocks: func(mockTimelineService *mocks.DayUserTimelineFilledService) {
+				mockTimelineService.On("GetDayUserTimelineFilled", mock.Anything, mock.Anything).Return(nil, timeline.ErrUserTimelineNotFound).Once()
+			},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "should return unavailable when posts service is down",
+			request: &timelinev1.GetTimelineRequest{
+				UserId: "user-456",
+				From:   &timelinev1.Date{Year: 2025, Month: 5, Day: 21},
+				To:     &timelinev1.Date{Year: 2025, Month: 5, Day: 21},
+			},
+			setupMocks: func(mockTimelineService *mocks.DayUserTimelineFilledService) {
+				mockTimelineService.On("GetDayUserTimelineFilled", mock.Anything, mock.Anything).Return(nil, posts.ErrUpstreamUnavailable).Once()
+			},
+			expectedCode: codes.Unavailable,
+		},
+		{
+			name: "should return internal error",
+			request: &timelinev1.GetTimelineRequest{
+				UserId: "user-456",
+				From:   &timelinev1.Date{Year: 2025, Month: 5, Day: 21},
+				To:     &timelinev1.Date{Year: 2025, Month: 5, Day: 21},
+			},
+			setupMocks: func(mockTimelineService *mocks.DayUserTimelineFilledService) {
+				mockTimelineService.On("GetDayUserTimelineFilled", mock.Anything, mock.Anything).Return(nil, errors.New("boom")).Once()
+			},
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockTimelineService)
+			}
+			client := timelinev1.NewTimelineServiceClient(setupClient(t, &config.Dependencies{
+				TimelineService:      mockTimelineService,
+				ReadMarkerRepository: infrastructure.NewInmemReadMarkerRepository(),
+			}))
+
+			// Act
+			response, err := client.GetTimeline(asUser("user-456"), tt.request)
+
+			// Assert
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode != codes.OK {
+				var violations []string
+				for _, detail := range status.Convert(err).Details() {
+					if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+						for _, violation := range badRequest.GetFieldViolations() {
+							violations = append(violations, violation.GetField())
+						}
+					}
+				}
+				assert.Equal(t, tt.expectedViolations, violations)
+				return
+			}
+
+			var postIDs []string
+			for _, post := range response.GetPosts() {
+				postIDs = append(postIDs, post.GetId())
+			}
+			assert.Equal(t, tt.expectedPostIDs, postIDs)
+			assert.Equal(t, "user-456", response.GetUserId())
+			assert.Equal(t, int32(2), response.GetUnreadCount())
+			assert.Equal(t, text, response.GetPosts()[1].GetContents()[0].GetText())
+		})
+	}
+}
+
+func TestAddAndRemovePostFromTimeline(t *testing.T) {
+	// Setup
+	ctx := withBearerToken(t, "feed-service", "timeline:service")
+	post := &posts.Post{ID: "post-123", AuthorID: "author-789", PublishedAt: time.Now()}
+	mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+	mockTimelineService.On("AddPost", mock.Anything, "post-123", "user-456", (*posts.Post)(nil)).Return(post, nil).Once()
+	mockTimelineService.On("RemovePost", mock.Anything, "post-123", "user-456").Return(post, nil).Once()
+	client := timelinev1.NewTimelineServiceClient(setupClientWithAuth(t, config.Auth{
+		JWT: config.JWT{HMACSecret: testHMACSecret},
+	}, &config.Dependencies{
+		TimelineService: mockTimelineService,
+		EventPublisher:  events.NewInMemoryBroker(),
+	}))
+
+	// Act
+	_, addErr := client.AddPostToTimeline(ctx, &timelinev1.AddPostToTimelineRequest{UserId: "user-456", PostId: "post-123"})
+	_, removeErr := client.RemovePostFromTimeline(ctx, &timelinev1.RemovePostFromTimelineRequest{UserId: "user-456", PostId: "post-123"})
+	_, invalidErr := client.AddPostToTimeline(ctx, &timelinev1.AddPostToTimelineRequest{UserId: "user-456"})
+
+	// Assert
+	assert.NoError(t, addErr)
+	assert.NoError(t, removeErr)
+	assert.Equal(t, codes.InvalidArgument, status.Code(invalidErr))
+}
+
+func TestBackfillTimeline(t *testing.T) {
+	// Setup
+	ctx := withBearerToken(t, "follows-service", "timeline:service")
+	authorPosts := []posts.Post{{ID: "post-123", AuthorID: "user-42", PublishedAt: time.Now()}}
+	mockPostRepository := mocks.NewPostRepository(t)
+	mockPostRepository.On("GetAuthorPosts", mock.Anything, "user-42", mock.Anything).Return(authorPosts, nil).Once()
+	mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+	mockTimelineService.On("BackfillPosts", mock.Anything, "user-1312", authorPosts).Return(nil).Once()
+	client := timelinev1.NewTimelineServiceClient(setupClientWithAuth(t, config.Auth{
+		JWT: config.JWT{HMACSecret: testHMACSecret},
+	}, &config.Dependencies{
+		TimelineService: mockTimelineService,
+		PostRepository:  mockPostRepository,
+	}))
+
+	// Act
+	_, err := client.BackfillTimeline(ctx, &timelinev1.BackfillTimelineRequest{FollowerId: "user-1312", FollowedId: "user-42"})
+	_, invalidErr := client.BackfillTimeline(ctx, &timelinev1.BackfillTimelineRequest{FollowerId: "user-1312"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(invalidErr))
+}
+
+func TestAuthorization(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          func(t *testing.T) context.Context
+		userID       string
+		expectedCode codes.Code
+	}{
+		{
+			name:         "should read own timeline",
+			ctx:          func(t *testing.T) context.Context { return withBearerToken(t, "user-456", "") },
+			userID:       "user-456",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "should let service scopes read any timeline",
+			ctx:          func(t *testing.T) context.Context { return withBearerToken(t, "feed-service", "timeline:service") },
+			userID:       "user-456",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "should forbid reading other users timeline",
+			ctx:          func(t *testing.T) context.Context { return withBearerToken(t, "user-other", "timeline:read") },
+			userID:       "user-456",
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "should require a bearer token",
+			ctx:          func(t *testing.T) context.Context { return context.Background() },
+			userID:       "user-456",
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "should not trust the user header with JWT keys",
+			ctx:          func(t *testing.T) context.Context { return asUser("user-456") },
+			userID:       "user-456",
+			expectedCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+			mockTimelineService.On("GetDayUserTimelineFilled", mock.Anything, mock.Anything).Return(&day_timeline_filled.DayUserTimelineFilled{
+				UserID: tt.userID,
+			}, nil).Maybe()
+			client := timelinev1.NewTimelineServiceClient(setupClientWithAuth(t, config.Auth{
+				JWT: config.JWT{HMACSecret: testHMACSecret},
+			}, &config.Dependencies{
+				TimelineService:      mockTimelineService,
+				ReadMarkerRepository: infrastructure.NewInmemReadMarkerRepository(),
+			}))
+
+			// Act
+			_, err := client.GetTimeline(tt.ctx(t), &timelinev1.GetTimelineRequest{
+				UserId: tt.userID,
+				From:   &timelinev1.Date{Year: 2025, Month: 5, Day: 21},
+				To:     &timelinev1.Date{Year: 2025, Month: 5, Day: 21},
+			})
+
+			// Assert
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestAuthorization_InternalCalls(t *testing.T) {
+	// Setup
+	ctx := withBearerToken(t, "user-456", "timeline:read")
+	client := timelinev1.NewTimelineServiceClient(setupClientWithAuth(t, config.Auth{
+		JWT: config.JWT{HMACSecret: testHMACSecret},
+	}, &config.Dependencies{}))
+
+	// Act
+	_, addErr := client.AddPostToTimeline(ctx, &timelinev1.AddPostToTimelineRequest{UserId: "user-456", PostId: "post-123"})
+	_, removeErr := client.RemovePostFromTimeline(ctx, &timelinev1.RemovePostFromTimelineRequest{UserId: "user-456", PostId: "post-123"})
+	_, backfillErr := client.BackfillTimeline(ctx, &timelinev1.BackfillTimelineRequest{FollowerId: "user-456", FollowedId: "user-42"})
+
+	// Assert
+	assert.Equal(t, codes.PermissionDenied, status.Code(addErr))
+	assert.Equal(t, codes.PermissionDenied, status.Code(removeErr))
+	assert.Equal(t, codes.PermissionDenied, status.Code(backfillErr))
+}
+
+func TestHealth(t *testing.T) {
+	// Setup
+	client := healthpb.NewHealthClient(setupClient(t, &config.Dependencies{}))
+
+	// Act
+	response, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: timelinev1.TimelineService_ServiceDesc.ServiceName})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, response.GetStatus())
+}
@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"errors"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"uala-timeline-service/internal/application"
+)
+
+var applicationCodes = map[string]codes.Code{
+	application.CodeInvalidArgument:     codes.InvalidArgument,
+	application.CodeNotFound:            codes.NotFound,
+	application.CodeUpstreamUnavailable: codes.Unavailable,
+	application.CodeResourceExhausted:   codes.ResourceExhausted,
+	application.CodeUnauthenticated:     codes.Unauthenticated,
+	application.CodePermissionDenied:    codes.PermissionDenied,
+	application.CodeInternal:            codes.Internal,
+}
+
+// toStatusError is the gRPC counterpart of handleError, the field violations are sent as
+// a BadRequest detail.
+func toStatusError(err error) error {
+	var appErr *application.Error
+	if !errors.As(err, &appErr) {
+		appErr = application.NewInternalError(err)
+	}
+
+	code, ok := applicationCodes[appErr.Code]
+	if !ok {
+		code = codes.Internal
+	}
+	if code == codes.Internal || code == codes.Unavailable {
+		log.Err(err).Msg("error handling grpc request")
+	}
+
+	st := status.New(code, appErr.Message)
+	if len(appErr.Violations) == 0 {
+		return st.Err()
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range appErr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Message,
+		})
+	}
+	detailed, detailsErr := st.WithDetails(badRequest)
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"uala-timeline-service/cmd/auth"
+)
+
+type authenticationErrorKey struct{}
+
+// authenticate stores the caller on the request context. Requests without valid
+// credentials go on without it so the rate limiter can still key them by API key or IP,
+// authorizeTimelineOwner rejects them.
+func authenticate(authenticator auth.Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, err := authenticator.Authenticate(r.Header)
+			if err != nil {
+				next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), authenticationErrorKey{}, err)))
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), principal)))
+		})
+	}
+}
+
+// authorizeTimelineOwner rejects the unauthenticated requests and lets callers reach only
+// their own {user_id} routes unless they hold one of the service scopes. It must run after
+// the route is matched so the URL param is set.
+func authorizeTimelineOwner(authorizer *auth.Authorizer) func(http.Handler) http.Handler {
+	return authorize(func(r *http.Request, principal *auth.Principal) error {
+		return authorizer.AuthorizeTimeline(principal, chi.URLParam(r, "user_id"))
+	})
+}
+
+// authorizeService rejects the callers without one of the service scopes.
+func authorizeService(authorizer *auth.Authorizer) func(http.Handler) http.Handler {
+	return authorize(func(_ *http.Request, principal *auth.Principal) error {
+		return authorizer.AuthorizeService(principal)
+	})
+}
+
+func authorize(check func(r *http.Request, principal *auth.Principal) error) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := auth.PrincipalFromContext(r.Context())
+			if !ok {
+				err, _ := r.Context().Value(authenticationErrorKey{}).(error)
+				if err == nil {
+					err = auth.ErrUnauthenticated
+				}
+				handleError(w, r, err)
+				return
+			}
+			if err := check(r, principal); err != nil {
+				handleError(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
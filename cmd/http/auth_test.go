@@ -0,0 +1,246 @@
+package http
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"uala-timeline-service/cmd/auth"
+	"uala-timeline-service/config"
+	"uala-timeline-service/internal/application"
+	"uala-timeline-service/internal/domain/day_timeline_filled"
+	"uala-timeline-service/internal/infrastructure"
+	"uala-timeline-service/mocks"
+)
+
+const testHMACSecret = "test-secret"
+
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims auth.TimelineClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func userClaims(subject string, scope string, expiresIn time.Duration) auth.TimelineClaims {
+	return auth.TimelineClaims{
+		Scope: scope,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			Audience:  jwt.ClaimStrings{"timeline-service"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+		},
+	}
+}
+
+func writeKeyFiles(t *testing.T, publicKey *rsa.PublicKey) (string, string) {
+	dir := t.TempDir()
+
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemFile := filepath.Join(dir, "public.pem")
+	if err := os.WriteFile(pemFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	keySet, _ := json.Marshal(map[string][]map[string]string{"keys": {{
+		"kty": "RSA",
+		"kid": "key-1",
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+	}}})
+	jwksFile := filepath.Join(dir, "jwks.json")
+	if err := os.WriteFile(jwksFile, keySet, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return pemFile, jwksFile
+}
+
+func TestJWTAuthentication(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherRSAKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemFile, jwksFile := writeKeyFiles(t, &rsaKey.PublicKey)
+	publicKeyPEM, _ := os.ReadFile(pemFile)
+
+	const target = "/api/v2/users/user-456/timeline?from=2025-05-21&to=2025-05-21"
+
+	tests := []struct {
+		name           string
+		jwtConfig      config.JWT
+		target         string
+		authorization  string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "should read own timeline with HS256 token",
+			jwtConfig:      config.JWT{HMACSecret: testHMACSecret},
+			target:         target,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testHMACSecret), "", userClaims("user-456", "", time.Hour)),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "should read own timeline with RS256 token and static key",
+			jwtConfig:      config.JWT{PublicKeyFile: pemFile, Audience: "timeline-service"},
+			target:         target,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodRS256, rsaKey, "", userClaims("user-456", "", time.Hour)),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "should read own timeline with RS256 token and JWKS key",
+			jwtConfig:      config.JWT{JWKSFile: jwksFile},
+			target:         target,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodRS256, rsaKey, "key-1", userClaims("user-456", "", time.Hour)),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "should let service scopes read any timeline",
+			jwtConfig:      config.JWT{HMACSecret: testHMACSecret},
+			target:         target,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testHMACSecret), "", userClaims("feed-service", "timeline:service", time.Hour)),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "should forbid reading other users timeline",
+			jwtConfig:      config.JWT{HMACSecret: testHMACSecret},
+			target:         target,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testHMACSecret), "", userClaims("user-other", "timeline:read", time.Hour)),
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   application.CodePermissionDenied,
+		},
+		{
+			name:           "should forbid reading other users read marker",
+			jwtConfig:      config.JWT{HMACSecret: testHMACSecret},
+			target:         "/api/v1/users/user-456/read_marker",
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testHMACSecret), "", userClaims("user-other", "", time.Hour)),
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   application.CodePermissionDenied,
+		},
+		{
+			name:           "should let service scopes read the debug vars",
+			jwtConfig:      config.JWT{HMACSecret: testHMACSecret},
+			target:         "/debug/vars",
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testHMACSecret), "", userClaims("feed-service", "timeline:admin", time.Hour)),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "should forbid users reading the debug vars",
+			jwtConfig:      config.JWT{HMACSecret: testHMACSecret},
+			target:         "/debug/vars",
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testHMACSecret), "", userClaims("user-456", "", time.Hour)),
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   application.CodePermissionDenied,
+		},
+		{
+			name:           "should require a bearer token",
+			jwtConfig:      config.JWT{HMACSecret: testHMACSecret},
+			target:         target,
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   application.CodeUnauthenticated,
+		},
+		{
+			name:           "should reject expired tokens",
+			jwtConfig:      config.JWT{HMACSecret: testHMACSecret},
+			target:         target,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testHMACSecret), "", userClaims("user-456", "", -time.Hour)),
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   application.CodeUnauthenticated,
+		},
+		{
+			name:           "should reject tokens signed with other keys",
+			jwtConfig:      config.JWT{JWKSFile: jwksFile},
+			target:         target,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodRS256, otherRSAKey, "key-1", userClaims("user-456", "", time.Hour)),
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   application.CodeUnauthenticated,
+		},
+		{
+			name:           "should reject HS256 tokens signed with the RSA public key",
+			jwtConfig:      config.JWT{PublicKeyFile: pemFile},
+			target:         target,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, publicKeyPEM, "", userClaims("user-456", "", time.Hour)),
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   application.CodeUnauthenticated,
+		},
+		{
+			name:           "should reject tokens for other audiences",
+			jwtConfig:      config.JWT{HMACSecret: testHMACSecret, Audience: "posts-service"},
+			target:         target,
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte(testHMACSecret), "", userClaims("user-456", "", time.Hour)),
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   application.CodeUnauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+			mockTimelineService.On("GetDayUserTimelineFilled", mock.Anything, mock.Anything).Return(&day_timeline_filled.DayUserTimelineFilled{
+				UserID: "user-456",
+			}, nil).Maybe()
+			router := SetupRouterAndRoutes(&config.Config{
+				ServiceName: "timeline-service",
+				Auth:        config.Auth{JWT: tt.jwtConfig},
+			}, &config.Dependencies{
+				TimelineService:      mockTimelineService,
+				ReadMarkerRepository: infrastructure.NewInmemReadMarkerRepository(),
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedCode == "" {
+				return
+			}
+			var problem Problem
+			assert.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+			assert.Equal(t, tt.expectedCode, problem.Code)
+		})
+	}
+}
+
+func TestSetupRouterAndRoutes_RequiresAuthenticator(t *testing.T) {
+	// Assert
+	assert.PanicsWithError(t, "fatal error building authenticator: "+auth.ErrNoAuthenticator.Error(), func() {
+		SetupRouterAndRoutes(&config.Config{
+			ServiceName: "timeline-service",
+			Auth:        config.Auth{UserHeader: "X-User-ID"},
+		}, &config.Dependencies{})
+	})
+}
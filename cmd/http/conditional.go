@@ -0,0 +1,61 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultCacheControl = "private, no-cache"
+
+// writeValidators sets the cache headers of a timeline read, they are sent on 200 and 304.
+func writeValidators(w http.ResponseWriter, cacheControl string, etag string, lastModified time.Time) {
+	if cacheControl == "" {
+		cacheControl = defaultCacheControl
+	}
+	w.Header().Set("Cache-Control", cacheControl)
+	if etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// notModified evaluates the conditional headers of the request. If-Modified-Since is
+// ignored when If-None-Match is present, as RFC 9110 requires.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return etagMatches(ifNoneMatch, etag)
+	}
+
+	ifModifiedSince := r.Header.Get("If-Modified-Since")
+	if ifModifiedSince == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// etagMatches uses the weak comparison, the W/ prefix of both tags is ignored.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	if etag == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func writeNotModified(w http.ResponseWriter) {
+	w.Header().Del("Content-Type")
+	w.WriteHeader(http.StatusNotModified)
+}
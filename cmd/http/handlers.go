@@ -2,84 +2,228 @@ package http
 
 import (
 	"encoding/json"
-	"errors"
 	"github.com/go-chi/chi/v5"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 	"uala-timeline-service/config"
 	"uala-timeline-service/internal/application"
 )
 
 var (
-	ErrInvalidUser = errors.New("invalid user")
+	ErrInvalidUser = application.NewValidationError("invalid user", nil, application.FieldViolation{Field: "user_id", Message: "is required"})
 )
 
+const isoDateLayout = "2006-01-02"
+
 func getUserTimelineByDay(deps *config.Dependencies) http.HandlerFunc {
-	createPost := application.NewGetUserTimeline(deps.TimelineService)
+	createPost := application.NewGetUserTimeline(deps.TimelineService, deps.ReadMarkerRepository, deps.UserProfileRepository, deps.PostStatsRepository, deps.Rankers)
 	return func(w http.ResponseWriter, r *http.Request) {
 		var cmd application.GetUserTimelineCommand
 		err := json.NewDecoder(r.Body).Decode(&cmd)
 		if err != nil {
-			handleError(w, err)
+			handleError(w, r, application.NewValidationError("request body must be a valid JSON", err))
 			return
 		}
 
 		userID := chi.URLParam(r, "user_id")
 		if userID == "" {
-			handleError(w, ErrInvalidUser)
+			handleError(w, r, ErrInvalidUser)
 			return
 		}
 		cmd.UserID = userID
 		response, err := createPost.Exec(r.Context(), &cmd)
 		if err != nil {
-			handleError(w, err)
+			handleError(w, r, err)
 			return
 		}
 
 		w.WriteHeader(http.StatusCreated)
 		if err := json.NewEncoder(w).Encode(response); err != nil {
-			handleError(w, err)
+			handleError(w, r, err)
+			return
+		}
+	}
+}
+
+func getUserTimeline(cfg *config.Config, deps *config.Dependencies) http.HandlerFunc {
+	getUserTimeline := application.NewGetUserTimeline(deps.TimelineService, deps.ReadMarkerRepository, deps.UserProfileRepository, deps.PostStatsRepository, deps.Rankers)
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := chi.URLParam(r, "user_id")
+		if userID == "" {
+			handleError(w, r, ErrInvalidUser)
+			return
+		}
+
+		cmd, err := parseGetUserTimelineQuery(r.URL.Query())
+		if err != nil {
+			handleError(w, r, err)
+			return
+		}
+		cmd.UserID = userID
+
+		response, err := getUserTimeline.Exec(r.Context(), cmd)
+		if err != nil {
+			handleError(w, r, err)
+			return
+		}
+
+		writeValidators(w, cfg.HTTP.CacheControl, response.ETag, response.LastModified)
+		if notModified(r, response.ETag, response.LastModified) {
+			writeNotModified(w)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			handleError(w, r, err)
+			return
+		}
+	}
+}
+
+func getNewPostsCount(cfg *config.Config, deps *config.Dependencies) http.HandlerFunc {
+	countNewPosts := application.NewCountNewPosts(deps.TimelineService, cfg.NewCount.Cap, cfg.NewCount.MaxPostIDs)
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := chi.URLParam(r, "user_id")
+		if userID == "" {
+			handleError(w, r, ErrInvalidUser)
+			return
+		}
+
+		cmd := &application.CountNewPostsCommand{
+			UserID: userID,
+			Since:  r.URL.Query().Get("since"),
+		}
+		if includePostIDs := r.URL.Query().Get("include_post_ids"); includePostIDs != "" {
+			parsedIncludePostIDs, err := strconv.Atoi(includePostIDs)
+			if err != nil {
+				handleError(w, r, application.NewValidationError("invalid new posts count request", err, application.FieldViolation{Field: "include_post_ids", Message: "must be a number"}))
+				return
+			}
+			cmd.IncludePostIDs = parsedIncludePostIDs
+		}
+
+		response, err := countNewPosts.Exec(r.Context(), cmd)
+		if err != nil {
+			handleError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			handleError(w, r, err)
 			return
 		}
 	}
 }
 
-func handleError(w http.ResponseWriter, err error) {
-	if err == nil {
-		return
+func getReadMarker(deps *config.Dependencies) http.HandlerFunc {
+	getReadMarker := application.NewGetReadMarker(deps.ReadMarkerRepository)
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := chi.URLParam(r, "user_id")
+		if userID == "" {
+			handleError(w, r, ErrInvalidUser)
+			return
+		}
+
+		response, err := getReadMarker.Exec(r.Context(), &application.GetReadMarkerCommand{
+			UserID:   userID,
+			DeviceID: r.URL.Query().Get("device_id"),
+		})
+		if err != nil {
+			handleError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			handleError(w, r, err)
+			return
+		}
 	}
+}
 
-	var errorResp ErrorResponse
+func setReadMarker(deps *config.Dependencies) http.HandlerFunc {
+	setReadMarker := application.NewSetReadMarker(deps.ReadMarkerRepository)
+	return func(w http.ResponseWriter, r *http.Request) {
+		var cmd application.SetReadMarkerCommand
+		err := json.NewDecoder(r.Body).Decode(&cmd)
+		if err != nil {
+			handleError(w, r, application.NewValidationError("request body must be a valid JSON", err))
+			return
+		}
 
-	switch {
-	case errors.Is(err, ErrInvalidUser):
-		errorResp = ErrorResponse{
-			StatusCode: http.StatusBadRequest,
-			Message:    "Invalid user",
-			Code:       "BAD_REQUEST",
+		userID := chi.URLParam(r, "user_id")
+		if userID == "" {
+			handleError(w, r, ErrInvalidUser)
+			return
 		}
-	default:
-		errorResp = ErrorResponse{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "Internal server error",
-			Code:       "INTERNAL_ERROR",
+		cmd.UserID = userID
+
+		response, err := setReadMarker.Exec(r.Context(), &cmd)
+		if err != nil {
+			handleError(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			handleError(w, r, err)
+			return
 		}
 	}
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(errorResp.StatusCode)
+// parseGetUserTimelineQuery reads from and to as ISO-8601 dates. Only the format is checked
+// here, the values are validated by the use case.
+func parseGetUserTimelineQuery(query url.Values) (*application.GetUserTimelineCommand, error) {
+	var violations []application.FieldViolation
+	cmd := &application.GetUserTimelineCommand{
+		Timezone: query.Get("timezone"),
+		Cursor:   query.Get("cursor"),
+		DeviceID: query.Get("device_id"),
+		Sort:     query.Get("sort"),
+	}
 
-	jsonResp, jsonErr := json.Marshal(errorResp)
-	if jsonErr != nil {
-		http.Error(w, "Error processing response", http.StatusInternalServerError)
-		return
+	for _, expand := range query["expand"] {
+		cmd.Expand = append(cmd.Expand, strings.Split(expand, ",")...)
 	}
 
-	w.Write(jsonResp)
-	return
+	if from, ok := parseISODate(query, "from", &violations); ok {
+		cmd.FromYear, cmd.FromMonth, cmd.FromDay = from.Year(), int(from.Month()), from.Day()
+	}
+	if to, ok := parseISODate(query, "to", &violations); ok {
+		cmd.ToYear, cmd.ToMonth, cmd.ToDay = to.Year(), int(to.Month()), to.Day()
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil || parsedLimit < 1 {
+			violations = append(violations, application.FieldViolation{Field: "limit", Message: "must be a positive number"})
+		}
+		cmd.Limit = parsedLimit
+	}
+
+	if len(violations) > 0 {
+		return nil, application.NewValidationError("invalid timeline request", nil, violations...)
+	}
+	return cmd, nil
 }
 
-type ErrorResponse struct {
-	StatusCode int    `json:"status,omitempty"`
-	Message    string `json:"message,omitempty"`
-	Code       string `json:"code,omitempty"`
+func parseISODate(query url.Values, field string, violations *[]application.FieldViolation) (time.Time, bool) {
+	value := query.Get(field)
+	if value == "" {
+		*violations = append(*violations, application.FieldViolation{Field: field, Message: "is required"})
+		return time.Time{}, false
+	}
+
+	date, err := time.Parse(isoDateLayout, value)
+	if err != nil {
+		*violations = append(*violations, application.FieldViolation{Field: field, Message: "must be a valid date with format YYYY-MM-DD"})
+		return time.Time{}, false
+	}
+	return date, true
 }
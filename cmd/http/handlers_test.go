@@ -0,0 +1,426 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"uala-timeline-service/config"
+	"uala-timeline-service/internal/application"
+	"uala-timeline-service/internal/domain/day_timeline_filled"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/internal/domain/timeline"
+	"uala-timeline-service/internal/domain/users"
+	"uala-timeline-service/internal/infrastructure"
+	"uala-timeline-service/mocks"
+)
+
+// trustGatewayHeader authenticates the test requests with the X-User-ID header.
+var trustGatewayHeader = config.Auth{TrustGatewayHeader: true}
+
+func TestGetUserTimeline(t *testing.T) {
+	now := time.Date(2025, 5, 21, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		method             string
+		target             string
+		body               string
+		setupMocks         func(mockTimelineService *mocks.DayUserTimelineFilledService)
+		expectedStatus     int
+		expectedCode       string
+		expectedViolations []string
+	}{
+		{
+			name:   "should return timeline",
+			method: http.MethodGet,
+			target: "/api/v2/users/user-456/timeline?from=2025-05-21&to=2025-05-21",
+			setupMocks: func(mockTimelineService *mocks.DayUserTimelineFilledService) {
+				mockTimelineService.On("GetDayUserTimelineFilled", mock.Anything, mock.MatchedBy(func(f day_timeline_filled.DayUserTimelineFilledFilter) bool {
+					return f.UserID == "user-456" && f.FromDay == 21 && f.ToDay == 21 && f.FromMonth == 5 && f.ToMonth == 5
+				})).Return(&day_timeline_filled.DayUserTimelineFilled{
+					UserID: "user-456",
+					Posts:  []posts.Post{{ID: "post-123", AuthorID: "author-789", PublishedAt: now}},
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:               "should require dates",
+			method:             http.MethodGet,
+			target:             "/api/v2/users/user-456/timeline",
+			expectedStatus:     http.StatusBadRequest,
+			expectedCode:       application.CodeInvalidArgument,
+			expectedViolations: []string{"from", "to"},
+		},
+		{
+			name:               "should reject impossible dates",
+			method:             http.MethodGet,
+			target:             "/api/v2/users/user-456/timeline?from=2025-02-30&to=2025-03-01",
+			expectedStatus:     http.StatusBadRequest,
+			expectedCode:       application.CodeInvalidArgument,
+			expectedViolations: []string{"from"},
+		},
+		{
+			name:               "should reject from after to",
+			method:             http.MethodGet,
+			target:             "/api/v2/users/user-456/timeline?from=2025-05-28&to=2025-05-21",
+			expectedStatus:     http.StatusBadRequest,
+			expectedCode:       application.CodeInvalidArgument,
+			expectedViolations: []string{"to"},
+		},
+		{
+			name:               "should reject ranges longer than the cap",
+			method:             http.MethodGet,
+			target:             "/api/v2/users/user-456/timeline?from=2025-01-01&to=2025-05-21",
+			expectedStatus:     http.StatusBadRequest,
+			expectedCode:       application.CodeInvalidArgument,
+			expectedViolations: []string{"to"},
+		},
+		{
+			name:               "should reject invalid limit and timezone",
+			method:             http.MethodGet,
+			target:             "/api/v2/users/user-456/timeline?from=2025-05-21&to=2025-05-21&limit=1000&timezone=Mars/Olympus",
+			expectedStatus:     http.StatusBadRequest,
+			expectedCode:       application.CodeInvalidArgument,
+			expectedViolations: []string{"limit", "timezone"},
+		},
+		{
+			name:               "should reject unknown expansions",
+			method:             http.MethodGet,
+			target:             "/api/v2/users/user-456/timeline?from=2025-05-21&to=2025-05-21&expand=author,comments",
+			expectedStatus:     http.StatusBadRequest,
+			expectedCode:       application.CodeInvalidArgument,
+			expectedViolations: []string{"expand"},
+		},
+		{
+			name:               "should reject unknown sorts",
+			method:             http.MethodGet,
+			target:             "/api/v2/users/user-456/timeline?from=2025-05-21&to=2025-05-21&sort=oldest",
+			expectedStatus:     http.StatusBadRequest,
+			expectedCode:       application.CodeInvalidArgument,
+			expectedViolations: []string{"sort"},
+		},
+		{
+			name:   "should map timeline not found to 404",
+			method: http.MethodGet,
+			target: "/api/v2/users/user-456/timeline?from=2025-05-21&to=2025-05-21",
+			setupMocks: func(mockTimelineService *mocks.DayUserTimelineFilledService) {
+				mockTimelineService.On("GetDayUserTimelineFilled", mock.Anything, mock.Anything).Return(nil, timeline.ErrUserTimelineNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   application.CodeNotFound,
+		},
+		{
+			name:   "should map posts service failures to 503",
+			method: http.MethodGet,
+			target: "/api/v2/users/user-456/timeline?from=2025-05-21&to=2025-05-21",
+			setupMocks: func(mockTimelineService *mocks.DayUserTimelineFilledService) {
+				mockTimelineService.On("GetDayUserTimelineFilled", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: timeout", posts.ErrUpstreamUnavailable)).Once()
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedCode:   application.CodeUpstreamUnavailable,
+		},
+		{
+			name:   "should map unknown errors to 500",
+			method: http.MethodGet,
+			target: "/api/v2/users/user-456/timeline?from=2025-05-21&to=2025-05-21",
+			setupMocks: func(mockTimelineService *mocks.DayUserTimelineFilledService) {
+				mockTimelineService.On("GetDayUserTimelineFilled", mock.Anything, mock.Anything).Return(nil, errors.New("boom")).Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   application.CodeInternal,
+		},
+		{
+			name:           "should reject invalid JSON on v1",
+			method:         http.MethodPost,
+			target:         "/api/v1/user_timeline/user-456",
+			body:           "{not json",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   application.CodeInvalidArgument,
+		},
+		{
+			name:               "should require dates on v1",
+			method:             http.MethodPost,
+			target:             "/api/v1/user_timeline/user-456",
+			body:               `{"from_day": 21, "from_month": 5, "from_year": 2025}`,
+			expectedStatus:     http.StatusBadRequest,
+			expectedCode:       application.CodeInvalidArgument,
+			expectedViolations: []string{"to"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockTimelineService)
+			}
+			router := SetupRouterAndRoutes(&config.Config{ServiceName: "timeline-service", Auth: trustGatewayHeader}, &config.Dependencies{
+				TimelineService:      mockTimelineService,
+				ReadMarkerRepository: infrastructure.NewInmemReadMarkerRepository(),
+			})
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("X-User-ID", "user-456")
+			rec := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedCode == "" {
+				return
+			}
+
+			assert.Equal(t, problemContentType, rec.Header().Get("Content-Type"))
+			var problem Problem
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, tt.expectedStatus, problem.Status)
+			assert.Equal(t, tt.expectedCode, problem.Code)
+			assert.Equal(t, req.URL.Path, problem.Instance)
+
+			fields := make([]string, len(problem.Violations))
+			for i, violation := range problem.Violations {
+				fields[i] = violation.Field
+			}
+			if tt.expectedViolations != nil {
+				assert.Equal(t, tt.expectedViolations, fields)
+			}
+		})
+	}
+}
+
+func TestGetUserTimeline_PostsAndMeta(t *testing.T) {
+	// Setup
+	firstDay := time.Date(2025, 5, 21, 12, 0, 0, 0, time.UTC)
+	secondDay := time.Date(2025, 5, 22, 9, 0, 0, 0, time.UTC)
+	mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+	mockTimelineService.On("GetDayUserTimelineFilled", mock.Anything, mock.Anything).Return(&day_timeline_filled.DayUserTimelineFilled{
+		UserID: "user-456",
+		Posts: []posts.Post{
+			{ID: "post-1", AuthorID: "author-789", PublishedAt: firstDay, UpdatedAt: firstDay},
+			{ID: "post-2", AuthorID: "author-789", PublishedAt: secondDay, UpdatedAt: secondDay.Add(time.Hour)},
+			{ID: "post-3", AuthorID: "author-789", PublishedAt: secondDay, UpdatedAt: secondDay},
+		},
+		Source: day_timeline_filled.SourceRebuilt,
+	}, nil).Once()
+	router := SetupRouterAndRoutes(&config.Config{ServiceName: "timeline-service", Auth: trustGatewayHeader}, &config.Dependencies{
+		TimelineService:      mockTimelineService,
+		ReadMarkerRepository: infrastructure.NewInmemReadMarkerRepository(),
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/users/user-456/timeline?from=2025-05-21&to=2025-05-22&timezone=America/Argentina/Buenos_Aires", nil)
+	req.Header.Set("X-User-ID", "user-456")
+	rec := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var response application.GetUserTimelineResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	var postIDs []string
+	var edited []bool
+	for _, post := range response.Posts {
+		postIDs = append(postIDs, post.ID)
+		edited = append(edited, post.Edited)
+	}
+	assert.Equal(t, []string{"post-3", "post-2", "post-1"}, postIDs)
+	assert.Equal(t, []bool{false, true, false}, edited)
+	assert.True(t, secondDay.Equal(response.Posts[0].PublishedAt))
+	assert.True(t, secondDay.Add(time.Hour).Equal(response.Posts[1].UpdatedAt))
+
+	buenosAires, _ := time.LoadLocation("America/Argentina/Buenos_Aires")
+	assert.True(t, time.Date(2025, 5, 21, 0, 0, 0, 0, buenosAires).Equal(response.Meta.From))
+	assert.True(t, time.Date(2025, 5, 22, 23, 59, 59, 999999999, buenosAires).Equal(response.Meta.To))
+	assert.Equal(t, "America/Argentina/Buenos_Aires", response.Meta.Timezone)
+	assert.Equal(t, day_timeline_filled.SourceRebuilt, response.Meta.Source)
+}
+
+func TestGetUserTimeline_ExpandAuthor(t *testing.T) {
+	// Setup
+	publishedAt := time.Date(2025, 5, 21, 12, 0, 0, 0, time.UTC)
+	mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+	mockTimelineService.On("GetDayUserTimelineFilled", mock.Anything, mock.Anything).Return(&day_timeline_filled.DayUserTimelineFilled{
+		UserID: "user-456",
+		Posts: []posts.Post{
+			{ID: "post-1", AuthorID: "author-789", PublishedAt: publishedAt, UpdatedAt: publishedAt},
+			{ID: "post-2", AuthorID: "author-unknown", PublishedAt: publishedAt.Add(time.Hour), UpdatedAt: publishedAt.Add(time.Hour)},
+		},
+	}, nil).Once()
+	userProfileRepository := infrastructure.NewInmemUserProfileRepository()
+	userProfileRepository.Save(users.UserProfile{UserID: "author-789", Username: "ana", DisplayName: "Ana"})
+	router := SetupRouterAndRoutes(&config.Config{ServiceName: "timeline-service", Auth: trustGatewayHeader}, &config.Dependencies{
+		TimelineService:       mockTimelineService,
+		ReadMarkerRepository:  infrastructure.NewInmemReadMarkerRepository(),
+		UserProfileRepository: userProfileRepository,
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/users/user-456/timeline?from=2025-05-21&to=2025-05-21&expand=author", nil)
+	req.Header.Set("X-User-ID", "user-456")
+	rec := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var response application.GetUserTimelineResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	assert.Nil(t, response.Posts[0].Author)
+	assert.Equal(t, &application.Author{ID: "author-789", Username: "ana", DisplayName: "Ana"}, response.Posts[1].Author)
+}
+
+func TestGetUserTimeline_ConditionalRequests(t *testing.T) {
+	lastUpdate := time.Date(2025, 5, 21, 12, 0, 0, 0, time.UTC)
+	target := "/api/v2/users/user-456/timeline?from=2025-05-21&to=2025-05-21"
+
+	tests := []struct {
+		name           string
+		headers        func(etag string) map[string]string
+		expectedStatus int
+	}{
+		{
+			name:           "should return timeline without conditional headers",
+			headers:        func(etag string) map[string]string { return nil },
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "should return not modified when the etag matches",
+			headers:        func(etag string) map[string]string { return map[string]string{"If-None-Match": etag} },
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name: "should return not modified when a weak etag on the list matches",
+			headers: func(etag string) map[string]string {
+				return map[string]string{"If-None-Match": `"other", W/` + etag}
+			},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:           "should return timeline when the etag does not match",
+			headers:        func(etag string) map[string]string { return map[string]string{"If-None-Match": `"other"`} },
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "should return not modified when not modified since",
+			headers: func(etag string) map[string]string {
+				return map[string]string{"If-Modified-Since": lastUpdate.Format(http.TimeFormat)}
+			},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name: "should return timeline when modified since",
+			headers: func(etag string) map[string]string {
+				return map[string]string{"If-Modified-Since": lastUpdate.Add(-time.Minute).Format(http.TimeFormat)}
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "should ignore if modified since when if none match is present",
+			headers: func(etag string) map[string]string {
+				return map[string]string{
+					"If-None-Match":     `"other"`,
+					"If-Modified-Since": lastUpdate.Format(http.TimeFormat),
+				}
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+			mockTimelineService.On("GetDayUserTimelineFilled", mock.Anything, mock.Anything).Return(&day_timeline_filled.DayUserTimelineFilled{
+				UserID:      "user-456",
+				LastUpdate:  lastUpdate,
+				DayVersions: map[string]time.Time{"2025:5:20": lastUpdate.Add(-time.Hour), "2025:5:21": lastUpdate},
+				Posts:       []posts.Post{{ID: "post-123", AuthorID: "author-789", PublishedAt: lastUpdate}},
+				Source:      day_timeline_filled.SourceCache,
+			}, nil)
+			router := SetupRouterAndRoutes(&config.Config{
+				ServiceName: "timeline-service",
+				Auth:        trustGatewayHeader,
+				HTTP:        config.HTTP{CacheControl: "private, max-age=30"},
+			}, &config.Dependencies{
+				TimelineService:      mockTimelineService,
+				ReadMarkerRepository: infrastructure.NewInmemReadMarkerRepository(),
+			})
+
+			firstReq := httptest.NewRequest(http.MethodGet, target, nil)
+			firstReq.Header.Set("X-User-ID", "user-456")
+			first := httptest.NewRecorder()
+			router.ServeHTTP(first, firstReq)
+			etag := first.Header().Get("ETag")
+			assert.NotEmpty(t, etag)
+
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			req.Header.Set("X-User-ID", "user-456")
+			for key, value := range tt.headers(etag) {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, etag, rec.Header().Get("ETag"))
+			assert.Equal(t, lastUpdate.Format(http.TimeFormat), rec.Header().Get("Last-Modified"))
+			assert.Equal(t, "private, max-age=30", rec.Header().Get("Cache-Control"))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetUserTimeline_ConditionalRequestsRebuilt(t *testing.T) {
+	// Setup
+	publishedAt := time.Date(2025, 5, 21, 12, 0, 0, 0, time.UTC)
+	target := "/api/v2/users/user-456/timeline?from=2025-05-21&to=2025-05-21"
+	mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+	mockTimelineService.On("GetDayUserTimelineFilled", mock.Anything, mock.Anything).Return(func(context.Context, day_timeline_filled.DayUserTimelineFilledFilter) *day_timeline_filled.DayUserTimelineFilled {
+		// Rebuilt reads are not stored, every read has a new last update
+		return &day_timeline_filled.DayUserTimelineFilled{
+			UserID:     "user-456",
+			LastUpdate: time.Now(),
+			Posts:      []posts.Post{{ID: "post-123", AuthorID: "author-789", PublishedAt: publishedAt}},
+			Source:     day_timeline_filled.SourcePartial,
+		}
+	}, nil)
+	router := SetupRouterAndRoutes(&config.Config{ServiceName: "timeline-service", Auth: trustGatewayHeader}, &config.Dependencies{
+		TimelineService:      mockTimelineService,
+		ReadMarkerRepository: infrastructure.NewInmemReadMarkerRepository(),
+	})
+
+	firstReq := httptest.NewRequest(http.MethodGet, target, nil)
+	firstReq.Header.Set("X-User-ID", "user-456")
+	first := httptest.NewRecorder()
+	router.ServeHTTP(first, firstReq)
+	etag := first.Header().Get("ETag")
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("X-User-ID", "user-456")
+	req.Header.Set("If-None-Match", etag)
+	rec := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(rec, req)
+
+	// Assert
+	assert.True(t, strings.HasPrefix(etag, "W/"))
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Equal(t, etag, rec.Header().Get("ETag"))
+}
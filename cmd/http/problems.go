@@ -0,0 +1,57 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"uala-timeline-service/internal/application"
+)
+
+const problemContentType = "application/problem+json"
+
+// Problem is the RFC 7807 body returned for every error, code and violations are
+// extension members.
+type Problem struct {
+	Type       string                       `json:"type"`
+	Title      string                       `json:"title"`
+	Status     int                          `json:"status"`
+	Detail     string                       `json:"detail,omitempty"`
+	Instance   string                       `json:"instance,omitempty"`
+	Code       string                       `json:"code"`
+	Violations []application.FieldViolation `json:"violations,omitempty"`
+}
+
+func handleError(w http.ResponseWriter, r *http.Request, err error) {
+	if err == nil {
+		return
+	}
+
+	var appErr *application.Error
+	if !errors.As(err, &appErr) {
+		appErr = application.NewInternalError(err)
+	}
+
+	problem := Problem{
+		Type:       "about:blank",
+		Title:      http.StatusText(appErr.Status),
+		Status:     appErr.Status,
+		Detail:     appErr.Message,
+		Instance:   r.URL.Path,
+		Code:       appErr.Code,
+		Violations: appErr.Violations,
+	}
+	if appErr.Status >= http.StatusInternalServerError {
+		log.Err(err).Str("path", r.URL.Path).Msg("error handling request")
+	}
+
+	jsonResp, jsonErr := json.Marshal(problem)
+	if jsonErr != nil {
+		http.Error(w, "Error processing response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(problem.Status)
+	w.Write(jsonResp)
+}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/rs/zerolog/log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+	"uala-timeline-service/cmd/auth"
+	"uala-timeline-service/internal/application"
+	"uala-timeline-service/libs/ratelimit"
+)
+
+var ErrRateLimited = application.NewResourceExhaustedError("rate limit exceeded")
+
+// rateLimit takes a token of the caller bucket of the route. Callers are the authenticated
+// user, the API key or the IP, in that order. The limiter failing lets the request through
+// so the store is never a single point of failure.
+func rateLimit(limiter ratelimit.Limiter, route string, limit ratelimit.Limit, apiKeyHeader string) func(http.Handler) http.Handler {
+	policy := strconv.Itoa(limit.Requests) + ";w=" + strconv.Itoa(int(limit.Period.Seconds()))
+	return func(next http.Handler) http.Handler {
+		if !limit.Enabled() {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			result, err := limiter.Allow(r.Context(), route+":"+rateLimitKey(r, apiKeyHeader), limit)
+			if err != nil {
+				log.Err(err).Str("route", route).Msg("error checking rate limit")
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("RateLimit-Policy", policy)
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+			if !result.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(max(ceilSeconds(result.RetryAfter), 1)))
+				handleError(w, r, ErrRateLimited)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitKey hashes the API key so it is not stored on the shared store.
+func rateLimitKey(r *http.Request, apiKeyHeader string) string {
+	if principal, ok := auth.PrincipalFromContext(r.Context()); ok {
+		return "user:" + principal.Subject
+	}
+	if apiKeyHeader != "" {
+		if apiKey := r.Header.Get(apiKeyHeader); apiKey != "" {
+			sum := sha256.Sum256([]byte(apiKey))
+			return "key:" + hex.EncodeToString(sum[:16])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"uala-timeline-service/config"
+	"uala-timeline-service/internal/domain/day_timeline_filled"
+	"uala-timeline-service/internal/infrastructure"
+	"uala-timeline-service/libs/ratelimit"
+	"uala-timeline-service/mocks"
+)
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store unavailable")
+}
+
+func TestRateLimit(t *testing.T) {
+	const target = "/api/v2/users/user-456/timeline?from=2025-05-21&to=2025-05-21"
+
+	type request struct {
+		userID string
+		apiKey string
+	}
+
+	tests := []struct {
+		name               string
+		limiter            ratelimit.Limiter
+		requests           []request
+		expectedStatuses   []int
+		expectedRemaining  []string
+		expectedRetryAfter string
+	}{
+		{
+			name:              "should limit each user with its own bucket",
+			limiter:           ratelimit.NewInMemoryLimiter(),
+			requests:          []request{{userID: "user-456"}, {userID: "user-456"}, {userID: "user-456"}, {userID: "user-other"}},
+			expectedStatuses:  []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests, http.StatusForbidden},
+			expectedRemaining: []string{"1", "0", "0", "1"},
+			// 2 requests every 60 seconds refill a token every 30 seconds
+			expectedRetryAfter: "30",
+		},
+		{
+			name:              "should limit unauthenticated callers by API key",
+			limiter:           ratelimit.NewInMemoryLimiter(),
+			requests:          []request{{apiKey: "key-1"}, {apiKey: "key-1"}, {apiKey: "key-1"}, {apiKey: "key-2"}},
+			expectedStatuses:  []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests, http.StatusUnauthorized},
+			expectedRemaining: []string{"1", "0", "0", "1"},
+		},
+		{
+			name:              "should limit unauthenticated callers by IP",
+			limiter:           ratelimit.NewInMemoryLimiter(),
+			requests:          []request{{}, {}, {}},
+			expectedStatuses:  []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests},
+			expectedRemaining: []string{"1", "0", "0"},
+		},
+		{
+			name:              "should let requests through when the limiter fails",
+			limiter:           failingLimiter{},
+			requests:          []request{{userID: "user-456"}, {userID: "user-456"}, {userID: "user-456"}},
+			expectedStatuses:  []int{http.StatusOK, http.StatusOK, http.StatusOK},
+			expectedRemaining: []string{"", "", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+			mockTimelineService.On("GetDayUserTimelineFilled", mock.Anything, mock.Anything).Return(&day_timeline_filled.DayUserTimelineFilled{
+				UserID: "user-456",
+			}, nil).Maybe()
+			router := SetupRouterAndRoutes(&config.Config{
+				ServiceName: "timeline-service",
+				Auth:        trustGatewayHeader,
+				RateLimit: config.RateLimit{
+					APIKeyHeader: "X-API-Key",
+					Default:      config.RouteRateLimit{Requests: 100, PeriodSeconds: 60},
+					Routes: map[string]config.RouteRateLimit{
+						"timeline": {Requests: 2, PeriodSeconds: 60},
+					},
+				},
+			}, &config.Dependencies{
+				TimelineService:      mockTimelineService,
+				ReadMarkerRepository: infrastructure.NewInmemReadMarkerRepository(),
+				RateLimiter:          tt.limiter,
+			})
+
+			var statuses []int
+			var remaining []string
+			var lastRetryAfter string
+			for _, request := range tt.requests {
+				req := httptest.NewRequest(http.MethodGet, target, nil)
+				if request.userID != "" {
+					req.Header.Set("X-User-ID", request.userID)
+				}
+				if request.apiKey != "" {
+					req.Header.Set("X-API-Key", request.apiKey)
+				}
+				rec := httptest.NewRecorder()
+
+				// Act
+				router.ServeHTTP(rec, req)
+
+				statuses = append(statuses, rec.Code)
+				remaining = append(remaining, rec.Header().Get("RateLimit-Remaining"))
+				lastRetryAfter = rec.Header().Get("Retry-After")
+				if rec.Code == http.StatusTooManyRequests {
+					assert.Equal(t, "2", rec.Header().Get("RateLimit-Limit"))
+					assert.Equal(t, "2;w=60", rec.Header().Get("RateLimit-Policy"))
+					assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+					assert.Equal(t, problemContentType, rec.Header().Get("Content-Type"))
+					if tt.expectedRetryAfter != "" {
+						assert.Equal(t, tt.expectedRetryAfter, rec.Header().Get("Retry-After"))
+					}
+				}
+			}
+
+			// Assert
+			assert.Equal(t, tt.expectedStatuses, statuses)
+			assert.Equal(t, tt.expectedRemaining, remaining)
+			if tt.expectedStatuses[len(tt.expectedStatuses)-1] != http.StatusTooManyRequests {
+				assert.Empty(t, lastRetryAfter)
+			}
+		})
+	}
+}
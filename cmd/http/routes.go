@@ -1,11 +1,15 @@
 package http
 
 import (
+	"expvar"
+	"fmt"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	ddchi "gopkg.in/DataDog/dd-trace-go.v1/contrib/go-chi/chi.v5"
 	"net/http"
+	"uala-timeline-service/cmd/auth"
 	"uala-timeline-service/config"
+	"uala-timeline-service/libs/ratelimit"
 )
 
 func SetupRouterAndRoutes(config *config.Config, deps *config.Dependencies) chi.Router {
@@ -17,10 +21,52 @@ func SetupRouterAndRoutes(config *config.Config, deps *config.Dependencies) chi.
 		})
 	})
 
+	authenticator, err := auth.NewAuthenticator(config.Auth)
+	if err != nil {
+		panic(fmt.Errorf("fatal error building authenticator: %w", err))
+	}
+	authorizer := auth.NewAuthorizer(config.Auth.ServiceScopes)
+	authorizeOwner := authorizeTimelineOwner(authorizer)
+
+	// Counters published with expvar, like the post cache hit ratio. Only for service
+	// callers, the cmdline and memstats are published too
+	router.With(authenticate(authenticator), authorizeService(authorizer)).Get("/debug/vars", expvar.Handler().ServeHTTP)
+
+	rateLimiter := deps.RateLimiter
+	if rateLimiter == nil {
+		rateLimiter = ratelimit.NewInMemoryLimiter()
+	}
+	limitRoute := func(route string) func(http.Handler) http.Handler {
+		return rateLimit(rateLimiter, route, config.RateLimit.Limit(route), config.RateLimit.APIKeyHeader)
+	}
+
+	// Deprecated: kept for compatibility, use GET /api/v2/users/{user_id}/timeline
 	router.Route("/api/v1/user_timeline", func(r chi.Router) {
 		r.Use(middleware.SetHeader("Content-Type", "application/json"))
 		r.Use(ddchi.Middleware(ddchi.WithServiceName(config.ServiceName)))
-		r.Post("/{user_id}", getUserTimelineByDay(deps))
+		r.Use(authenticate(authenticator))
+		r.With(limitRoute("timeline"), authorizeOwner).Post("/{user_id}", getUserTimelineByDay(deps))
+	})
+
+	streamLimiter := newConnectionLimiter(config.Stream.MaxConnections, config.Stream.MaxConnectionsPerUser)
+	router.Route("/api/v1/users", func(r chi.Router) {
+		r.Use(ddchi.Middleware(ddchi.WithServiceName(config.ServiceName)))
+		r.Use(authenticate(authenticator))
+		r.With(limitRoute("stream"), authorizeOwner).Get("/{user_id}/timeline/stream", streamUserTimeline(config, deps, streamLimiter))
+		r.With(limitRoute("stream"), authorizeOwner).Get("/{user_id}/timeline/ws", timelineWebSocket(config, deps, streamLimiter))
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.SetHeader("Content-Type", "application/json"))
+			r.With(limitRoute("new_count"), authorizeOwner).Get("/{user_id}/timeline/new_count", getNewPostsCount(config, deps))
+			r.With(limitRoute("read_marker"), authorizeOwner).Get("/{user_id}/read_marker", getReadMarker(deps))
+			r.With(limitRoute("read_marker"), authorizeOwner).Put("/{user_id}/read_marker", setReadMarker(deps))
+		})
+	})
+
+	router.Route("/api/v2/users", func(r chi.Router) {
+		r.Use(middleware.SetHeader("Content-Type", "application/json"))
+		r.Use(ddchi.Middleware(ddchi.WithServiceName(config.ServiceName)))
+		r.Use(authenticate(authenticator))
+		r.With(limitRoute("timeline"), authorizeOwner).Get("/{user_id}/timeline", getUserTimeline(config, deps))
 	})
 
 	return router
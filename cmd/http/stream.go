@@ -0,0 +1,90 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"time"
+	"uala-timeline-service/config"
+	"uala-timeline-service/internal/application"
+)
+
+const defaultHeartbeat = 15 * time.Second
+
+var (
+	ErrTooManyStreams  = application.NewResourceExhaustedError("too many open timeline streams")
+	ErrStreamsDisabled = application.NewInternalError(fmt.Errorf("streaming is not supported by the response writer"))
+)
+
+// streamUserTimeline serves the new posts of the user timeline as Server-Sent Events. The
+// event id is the post cursor, browsers send it back on Last-Event-ID when they reconnect.
+func streamUserTimeline(cfg *config.Config, deps *config.Dependencies, limiter *connectionLimiter) http.HandlerFunc {
+	streamUserTimeline := application.NewStreamUserTimeline(deps.TimelineService, deps.RelationshipRepository, deps.EventSubscriber)
+	heartbeat := cfg.Stream.Heartbeat()
+	if heartbeat <= 0 {
+		heartbeat = defaultHeartbeat
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := chi.URLParam(r, "user_id")
+		if userID == "" {
+			handleError(w, r, ErrInvalidUser)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			handleError(w, r, ErrStreamsDisabled)
+			return
+		}
+
+		if !limiter.acquire(userID) {
+			handleError(w, r, ErrTooManyStreams)
+			return
+		}
+		defer limiter.release(userID)
+
+		stream, err := streamUserTimeline.Exec(r.Context(), &application.StreamUserTimelineCommand{
+			UserID:      userID,
+			LastEventID: r.Header.Get("Last-Event-ID"),
+		})
+		if err != nil {
+			handleError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ticker.C:
+				if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+					return
+				}
+				flusher.Flush()
+			case event, ok := <-stream:
+				if !ok {
+					return
+				}
+				data, err := json.Marshal(event.Post)
+				if err != nil {
+					return
+				}
+				if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+					return
+				}
+				flusher.Flush()
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package http
+
+import "sync"
+
+// connectionLimiter counts the open streams of the replica, limits set to zero are not
+// enforced.
+type connectionLimiter struct {
+	mu         sync.Mutex
+	max        int
+	maxPerUser int
+	total      int
+	byUser     map[string]int
+}
+
+func newConnectionLimiter(max int, maxPerUser int) *connectionLimiter {
+	return &connectionLimiter{
+		max:        max,
+		maxPerUser: maxPerUser,
+		byUser:     make(map[string]int),
+	}
+}
+
+func (l *connectionLimiter) acquire(userID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.max > 0 && l.total >= l.max {
+		return false
+	}
+	if l.maxPerUser > 0 && l.byUser[userID] >= l.maxPerUser {
+		return false
+	}
+
+	l.total++
+	l.byUser[userID]++
+	return true
+}
+
+func (l *connectionLimiter) release(userID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.total--
+	l.byUser[userID]--
+	if l.byUser[userID] <= 0 {
+		delete(l.byUser, userID)
+	}
+}
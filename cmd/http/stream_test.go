@@ -0,0 +1,77 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"uala-timeline-service/config"
+	"uala-timeline-service/internal/application"
+	"uala-timeline-service/internal/domain"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/libs/events"
+	"uala-timeline-service/mocks"
+)
+
+func TestStreamUserTimeline(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	broker := events.NewInMemoryBroker()
+	mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+	mockRelationshipRepo := mocks.NewRelationshipRepository(t)
+	mockRelationshipRepo.On("GetBlockedAuthorIDs", mock.Anything, "user-456").Return(nil, nil).Maybe()
+	mockRelationshipRepo.On("GetMutedAuthorIDs", mock.Anything, "user-456").Return(nil, nil).Maybe()
+
+	server := httptest.NewServer(SetupRouterAndRoutes(&config.Config{
+		ServiceName: "timeline-service",
+		Auth:        trustGatewayHeader,
+		Stream:      config.Stream{HeartbeatSeconds: 60, MaxConnectionsPerUser: 1},
+	}, &config.Dependencies{
+		TimelineService:        mockTimelineService,
+		RelationshipRepository: mockRelationshipRepo,
+		EventSubscriber:        broker,
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/users/user-456/timeline/stream", nil)
+	req.Header.Set("X-User-ID", "user-456")
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// Act
+	post := posts.Post{ID: "post-123", AuthorID: "author-789", PublishedAt: time.Now()}
+	assert.NoError(t, broker.Publish(ctx, domain.NewTimelineStreamPostAddedEvent("user-456", post)))
+
+	limitedReq, _ := http.NewRequest(http.MethodGet, server.URL+"/api/v1/users/user-456/timeline/stream", nil)
+	limitedReq.Header.Set("X-User-ID", "user-456")
+	limited, err := http.DefaultClient.Do(limitedReq)
+	assert.NoError(t, err)
+	defer limited.Body.Close()
+
+	// Assert
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for len(lines) < 3 {
+		line, err := reader.ReadString('\n')
+		assert.NoError(t, err)
+		lines = append(lines, strings.TrimSuffix(line, "\n"))
+	}
+	assert.True(t, strings.HasPrefix(lines[0], "id: "))
+	assert.Equal(t, "event: post_added", lines[1])
+	var streamedPost application.Post
+	assert.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(lines[2], "data: ")), &streamedPost))
+	assert.Equal(t, "post-123", streamedPost.ID)
+
+	assert.Equal(t, http.StatusTooManyRequests, limited.StatusCode)
+	assert.Equal(t, problemContentType, limited.Header.Get("Content-Type"))
+}
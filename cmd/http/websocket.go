@@ -0,0 +1,110 @@
+package http
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"time"
+	"uala-timeline-service/config"
+	"uala-timeline-service/internal/application"
+)
+
+const (
+	wsWriteTimeout = 10 * time.Second
+	wsReadLimit    = 512
+)
+
+// wsMessage is written for every timeline change, ID is the post cursor that can be sent
+// back on last_event_id when reconnecting.
+type wsMessage struct {
+	Type string           `json:"type"`
+	ID   string           `json:"id"`
+	Post application.Post `json:"post"`
+}
+
+// timelineWebSocket pushes post_added, post_updated and post_removed messages of the
+// user timeline, the caller is authorized by the route middlewares. Readers that fall
+// behind are closed with 1013 so they reconnect and resume.
+func timelineWebSocket(cfg *config.Config, deps *config.Dependencies, limiter *connectionLimiter) http.HandlerFunc {
+	streamUserTimeline := application.NewStreamUserTimeline(deps.TimelineService, deps.RelationshipRepository, deps.EventSubscriber)
+	upgrader := websocket.Upgrader{}
+	heartbeat := cfg.Stream.Heartbeat()
+	if heartbeat <= 0 {
+		heartbeat = defaultHeartbeat
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := chi.URLParam(r, "user_id")
+		if userID == "" {
+			handleError(w, r, ErrInvalidUser)
+			return
+		}
+
+		if !limiter.acquire(userID) {
+			handleError(w, r, ErrTooManyStreams)
+			return
+		}
+		defer limiter.release(userID)
+
+		// The request context is not canceled when a hijacked connection is closed, the
+		// read loop cancels it instead.
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		stream, err := streamUserTimeline.Exec(ctx, &application.StreamUserTimelineCommand{
+			UserID:      userID,
+			LastEventID: r.URL.Query().Get("last_event_id"),
+		})
+		if err != nil {
+			handleError(w, r, err)
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		conn.SetReadLimit(wsReadLimit)
+		conn.SetReadDeadline(time.Now().Add(2 * heartbeat))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(2 * heartbeat))
+		})
+		go func() {
+			defer cancel()
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+			}
+		}()
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+					return
+				}
+			case event, ok := <-stream:
+				if !ok {
+					if ctx.Err() == nil {
+						closeMessage := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "reader is too slow")
+						conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(wsWriteTimeout))
+					}
+					return
+				}
+
+				conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+				if err := conn.WriteJSON(wsMessage{Type: event.Type, ID: event.ID, Post: event.Post}); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
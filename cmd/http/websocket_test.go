@@ -0,0 +1,104 @@
+package http
+
+import (
+	"context"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"uala-timeline-service/config"
+	"uala-timeline-service/internal/domain"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/libs/events"
+	"uala-timeline-service/mocks"
+)
+
+func TestTimelineWebSocket(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	post := posts.Post{ID: "post-123", AuthorID: "author-789", PublishedAt: time.Now()}
+
+	tests := []struct {
+		name             string
+		headerUserID     string
+		publish          []domain.TimelineStreamEvent
+		expectedStatus   int
+		expectedMessages []string
+	}{
+		{
+			name:             "should push added, updated and removed posts",
+			headerUserID:     "user-456",
+			publish:          []domain.TimelineStreamEvent{domain.NewTimelineStreamPostAddedEvent("user-456", post), domain.NewTimelineStreamPostUpdatedEvent("user-456", post), domain.NewTimelineStreamPostRemovedEvent("user-456", post)},
+			expectedStatus:   http.StatusSwitchingProtocols,
+			expectedMessages: []string{domain.TimelineStreamPostAdded, domain.TimelineStreamPostUpdated, domain.TimelineStreamPostRemoved},
+		},
+		{
+			name:           "should reject connections without credentials",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "should reject subscriptions to other timelines",
+			headerUserID:   "user-other",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			broker := events.NewInMemoryBroker()
+			mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+			mockRelationshipRepo := mocks.NewRelationshipRepository(t)
+			mockRelationshipRepo.On("GetBlockedAuthorIDs", mock.Anything, "user-456").Return(nil, nil).Maybe()
+			mockRelationshipRepo.On("GetMutedAuthorIDs", mock.Anything, "user-456").Return(nil, nil).Maybe()
+
+			server := httptest.NewServer(SetupRouterAndRoutes(&config.Config{
+				ServiceName: "timeline-service",
+				Auth:        config.Auth{TrustGatewayHeader: true, UserHeader: "X-User-ID"},
+			}, &config.Dependencies{
+				TimelineService:        mockTimelineService,
+				RelationshipRepository: mockRelationshipRepo,
+				EventSubscriber:        broker,
+			}))
+			defer server.Close()
+
+			header := http.Header{}
+			if tt.headerUserID != "" {
+				header.Set("X-User-ID", tt.headerUserID)
+			}
+			url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/users/user-456/timeline/ws"
+
+			// Act
+			conn, resp, err := websocket.DefaultDialer.Dial(url, header)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != http.StatusSwitchingProtocols {
+				assert.ErrorIs(t, err, websocket.ErrBadHandshake)
+				assert.Equal(t, problemContentType, resp.Header.Get("Content-Type"))
+				return
+			}
+			assert.NoError(t, err)
+			defer conn.Close()
+
+			for _, event := range tt.publish {
+				assert.NoError(t, broker.Publish(ctx, event))
+			}
+
+			var messageTypes []string
+			conn.SetReadDeadline(time.Now().Add(time.Second))
+			for range tt.expectedMessages {
+				var message wsMessage
+				assert.NoError(t, conn.ReadJSON(&message))
+				assert.Equal(t, "post-123", message.Post.ID)
+				assert.NotEmpty(t, message.ID)
+				messageTypes = append(messageTypes, message.Type)
+			}
+			assert.Equal(t, tt.expectedMessages, messageTypes)
+		})
+	}
+}
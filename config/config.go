@@ -6,16 +6,200 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
+	"uala-timeline-service/libs/ratelimit"
 )
 
 type Config struct {
-	ServiceName string      `mapstructure:"service_name"`
-	Env         string      `mapstructure:"env"`
-	Port        string      `mapstructure:"port"`
-	Postgres    Postgres    `mapstructure:"postgres"`
-	AWS         AWS         `mapstructure:"aws"`
-	RestConfigs RestConfigs `mapstructure:"rest_configs"`
-	Nats        Nats        `mapstructure:"nats"`
+	ServiceName   string        `mapstructure:"service_name"`
+	Env           string        `mapstructure:"env"`
+	Port          string        `mapstructure:"port"`
+	Postgres      Postgres      `mapstructure:"postgres"`
+	AWS           AWS           `mapstructure:"aws"`
+	RestConfigs   RestConfigs   `mapstructure:"rest_configs"`
+	Nats          Nats          `mapstructure:"nats"`
+	Backfill      Backfill      `mapstructure:"backfill"`
+	Relationships Relationships `mapstructure:"relationships"`
+	Profiles      Profiles      `mapstructure:"profiles"`
+	Fanout        Fanout        `mapstructure:"fanout"`
+	HTTP          HTTP          `mapstructure:"http"`
+	Stream        Stream        `mapstructure:"stream"`
+	Auth          Auth          `mapstructure:"auth"`
+	NewCount      NewCount      `mapstructure:"new_count"`
+	GRPC          GRPC          `mapstructure:"grpc"`
+	RateLimit     RateLimit     `mapstructure:"rate_limit"`
+	PostCache     PostCache     `mapstructure:"post_cache"`
+	Ranking       Ranking       `mapstructure:"ranking"`
+}
+
+// Ranking orders the timeline reads with DefaultSort, latest or top. UserSorts lists the
+// users that read with another sort, keyed by sort.
+type Ranking struct {
+	DefaultSort string              `mapstructure:"default_sort"`
+	UserSorts   map[string][]string `mapstructure:"user_sorts"`
+	Weights     RankingWeights      `mapstructure:"weights"`
+}
+
+// UserSortsByUser returns the sort of each user listed in UserSorts.
+func (r Ranking) UserSortsByUser() map[string]string {
+	userSorts := make(map[string]string)
+	for sort, userIDs := range r.UserSorts {
+		for _, userID := range userIDs {
+			userSorts[userID] = sort
+		}
+	}
+	return userSorts
+}
+
+// RankingWeights are the weights of the top sort score, the recency score halves every
+// RecencyHalfLifeMinutes.
+type RankingWeights struct {
+	Recency                float64 `mapstructure:"recency"`
+	RecencyHalfLifeMinutes int     `mapstructure:"recency_half_life_minutes"`
+	AuthorAffinity         float64 `mapstructure:"author_affinity"`
+	Likes                  float64 `mapstructure:"likes"`
+	Reposts                float64 `mapstructure:"reposts"`
+	Replies                float64 `mapstructure:"replies"`
+}
+
+func (w RankingWeights) RecencyHalfLife() time.Duration {
+	return time.Duration(w.RecencyHalfLifeMinutes) * time.Minute
+}
+
+// PostCache keeps the posts fetched from the posts service for TTLSeconds, on the memory of
+// each replica up to Size posts or on Redis when its address is set.
+type PostCache struct {
+	Enabled    bool  `mapstructure:"enabled"`
+	Size       int   `mapstructure:"size"`
+	TTLSeconds int   `mapstructure:"ttl_seconds"`
+	Redis      Redis `mapstructure:"redis"`
+}
+
+func (p PostCache) TTL() time.Duration {
+	return time.Duration(p.TTLSeconds) * time.Second
+}
+
+type Redis struct {
+	Address  string `mapstructure:"address"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+type RateLimit struct {
+	// Redis shares the buckets of every replica when its address is set, they are kept in
+	// memory otherwise
+	Redis Redis `mapstructure:"redis"`
+	// APIKeyHeader identifies the clients without a user
+	APIKeyHeader string                    `mapstructure:"api_key_header"`
+	Default      RouteRateLimit            `mapstructure:"default"`
+	Routes       map[string]RouteRateLimit `mapstructure:"routes"`
+}
+
+// Limit returns the limit of the route, the default one when the route has none.
+func (r RateLimit) Limit(route string) ratelimit.Limit {
+	routeLimit, ok := r.Routes[route]
+	if !ok {
+		routeLimit = r.Default
+	}
+	return ratelimit.Limit{
+		Requests: routeLimit.Requests,
+		Period:   time.Duration(routeLimit.PeriodSeconds) * time.Second,
+		Burst:    routeLimit.Burst,
+	}
+}
+
+// RouteRateLimit refills Requests tokens every PeriodSeconds up to Burst, zero disables it.
+type RouteRateLimit struct {
+	Requests      int `mapstructure:"requests"`
+	PeriodSeconds int `mapstructure:"period_seconds"`
+	Burst         int `mapstructure:"burst"`
+}
+
+type GRPC struct {
+	Port string `mapstructure:"port"`
+}
+
+type NewCount struct {
+	// Cap is the max count returned, has_more is set above it
+	Cap        int `mapstructure:"cap"`
+	MaxPostIDs int `mapstructure:"max_post_ids"`
+}
+
+type Auth struct {
+	// TrustGatewayHeader opts in to trust UserHeader when no JWT keys are configured, only
+	// for deployments where the API gateway is the only way to reach the service
+	TrustGatewayHeader bool `mapstructure:"trust_gateway_header"`
+	// UserHeader carries the user authenticated by the API gateway
+	UserHeader string `mapstructure:"user_header"`
+	JWT        JWT    `mapstructure:"jwt"`
+	// ServiceScopes let the caller read the timeline of any user
+	ServiceScopes []string `mapstructure:"service_scopes"`
+}
+
+// JWT holds the keys that verify the bearer tokens, HS256 with the secret and RS256 with
+// the public key file or the keys of the JWKS file.
+type JWT struct {
+	HMACSecret    string `mapstructure:"hmac_secret"`
+	PublicKeyFile string `mapstructure:"public_key_file"`
+	JWKSFile      string `mapstructure:"jwks_file"`
+	Issuer        string `mapstructure:"issuer"`
+	Audience      string `mapstructure:"audience"`
+}
+
+func (j JWT) Enabled() bool {
+	return j.HMACSecret != "" || j.PublicKeyFile != "" || j.JWKSFile != ""
+}
+
+// Stream limits the live timeline connections served by each replica, zero means no limit.
+type Stream struct {
+	HeartbeatSeconds      int `mapstructure:"heartbeat_seconds"`
+	MaxConnections        int `mapstructure:"max_connections"`
+	MaxConnectionsPerUser int `mapstructure:"max_connections_per_user"`
+}
+
+func (s Stream) Heartbeat() time.Duration {
+	return time.Duration(s.HeartbeatSeconds) * time.Second
+}
+
+type HTTP struct {
+	// CacheControl is sent on timeline reads, timelines are private to the reader
+	CacheControl string `mapstructure:"cache_control"`
+}
+
+type Fanout struct {
+	IncludeAuthor bool `mapstructure:"include_author"`
+	// PayloadMaxAgeSeconds is how long the post carried by a created post event is trusted
+	PayloadMaxAgeSeconds int `mapstructure:"payload_max_age_seconds"`
+}
+
+func (f Fanout) PayloadMaxAge() time.Duration {
+	return time.Duration(f.PayloadMaxAgeSeconds) * time.Second
+}
+
+// Relationships keeps the blocked and muted lists of up to CacheSize users for
+// CacheTTLSeconds.
+type Relationships struct {
+	CacheTTLSeconds int `mapstructure:"cache_ttl_seconds"`
+	CacheSize       int `mapstructure:"cache_size"`
+}
+
+// Profiles keeps up to CacheSize author profiles expanded on timeline reads for
+// CacheTTLSeconds.
+type Profiles struct {
+	CacheTTLSeconds int `mapstructure:"cache_ttl_seconds"`
+	CacheSize       int `mapstructure:"cache_size"`
+}
+
+func (p Profiles) CacheTTL() time.Duration {
+	return time.Duration(p.CacheTTLSeconds) * time.Second
+}
+
+type Backfill struct {
+	LookbackDays int `mapstructure:"lookback_days"`
+}
+
+func (b Backfill) Lookback() time.Duration {
+	return time.Duration(b.LookbackDays) * 24 * time.Hour
 }
 
 type Nats struct {
@@ -25,11 +209,22 @@ type Nats struct {
 type RestConfigs struct {
 	PostService      RestConfig `mapstructure:"post_service"`
 	FollowersService RestConfig `mapstructure:"followers_service"`
+	UsersService     RestConfig `mapstructure:"users_service"`
 }
 
 type RestConfig struct {
 	BasePath string `mapstructure:"base_path"`
-	Timeout  int    `mapstructure:"timeout"`
+	// Timeout of each attempt in milliseconds
+	Timeout        int `mapstructure:"timeout"`
+	Retries        int `mapstructure:"retries"`
+	RetryWaitMs    int `mapstructure:"retry_wait_ms"`
+	RetryMaxWaitMs int `mapstructure:"retry_max_wait_ms"`
+	// BreakerFailures consecutive failures open the breaker for BreakerOpenMs, zero disables it
+	BreakerFailures int `mapstructure:"breaker_failures"`
+	BreakerOpenMs   int `mapstructure:"breaker_open_ms"`
+	// MGetChunkSize and MGetConcurrency split the multi gets, only used by the posts service
+	MGetChunkSize   int `mapstructure:"mget_chunk_size"`
+	MGetConcurrency int `mapstructure:"mget_concurrency"`
 }
 
 type AWS struct {
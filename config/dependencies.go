@@ -1,29 +1,50 @@
 package config
 
 import (
+	"expvar"
 	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+	"time"
 	"uala-timeline-service/internal/domain/day_timeline_filled/service"
 	"uala-timeline-service/internal/domain/follows"
+	"uala-timeline-service/internal/domain/post_stats"
 	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/internal/domain/ranking"
+	"uala-timeline-service/internal/domain/read_markers"
+	"uala-timeline-service/internal/domain/relationships"
+	"uala-timeline-service/internal/domain/timeline"
+	"uala-timeline-service/internal/domain/users"
 	"uala-timeline-service/internal/infrastructure"
 	"uala-timeline-service/libs/events"
+	"uala-timeline-service/libs/ratelimit"
 )
 
 type Dependencies struct {
-	EventPublisher   events.Publisher
-	FollowRepository follows.FollowRepository
-	PostRepository   posts.PostRepository
-	TimelineService  service.DayUserTimelineFilledService
+	EventPublisher      events.Publisher
+	EventSubscriber     events.Subscriber
+	FollowRepository    follows.FollowRepository
+	PostRepository      posts.PostRepository
+	PostStatsRepository post_stats.PostStatsRepository
+	// PostCache is nil when the post cache is disabled
+	PostCache              posts.PostCache
+	Rankers                *ranking.Selector
+	RateLimiter            ratelimit.Limiter
+	ReadMarkerRepository   read_markers.ReadMarkerRepository
+	RelationshipRepository relationships.RelationshipRepository
+	TimelineRepository     timeline.TimelineRepository
+	TimelineService        service.DayUserTimelineFilledService
+	UserProfileRepository  users.UserProfileRepository
 }
 
 func BuildDependencies(config Config) (*Dependencies, error) {
 	// Nats boot
 	natsPublisher := events.NewNatsPublisher(config.Nats.Host)
+	natsSubscriber := events.NewNatsSubscriber(config.Nats.Host)
 
 	// Postgres boot
 	url := fmt.Sprintf(
@@ -63,15 +84,105 @@ func BuildDependencies(config Config) (*Dependencies, error) {
 
 	timelineRepository := infrastructure.NewTimelineRepository(db)
 	dayTimelineFilledRepository := infrastructure.NewDynamoPaymentRepository(dynamoDb, config.AWS.Table)
-	postRepository := infrastructure.NewRestPostRepository(config.RestConfigs.PostService.BasePath)
-	followsRepository := infrastructure.NewRestFollowsRepository(config.RestConfigs.FollowersService.BasePath)
+	// Follows and relationships share the followers service client, and its breaker
+	postServiceClient := infrastructure.NewRestClient(restClientConfig(config.RestConfigs.PostService))
+	followersServiceClient := infrastructure.NewRestClient(restClientConfig(config.RestConfigs.FollowersService))
+	usersServiceClient := infrastructure.NewRestClient(restClientConfig(config.RestConfigs.UsersService))
 
-	timelineService := service.NewTimelineService(timelineRepository, postRepository, dayTimelineFilledRepository)
+	restPostRepository := infrastructure.NewRestPostRepository(
+		config.RestConfigs.PostService.BasePath,
+		postServiceClient,
+		config.RestConfigs.PostService.MGetChunkSize,
+		config.RestConfigs.PostService.MGetConcurrency,
+	)
+	var postRepository posts.PostRepository = restPostRepository
+	var postCache posts.PostCache
+	if config.PostCache.Enabled {
+		cachedPostRepository := infrastructure.NewCachedPostRepository(postRepository, postCacheStore(config.PostCache))
+		expvar.Publish("post_cache", expvar.Func(func() any {
+			return cachedPostRepository.Stats()
+		}))
+		postRepository = cachedPostRepository
+		postCache = cachedPostRepository
+	}
+	followsRepository := infrastructure.NewRestFollowsRepository(config.RestConfigs.FollowersService.BasePath, followersServiceClient)
+	relationshipRepository := infrastructure.NewCachedRelationshipRepository(
+		infrastructure.NewRestRelationshipRepository(config.RestConfigs.FollowersService.BasePath, followersServiceClient),
+		time.Duration(config.Relationships.CacheTTLSeconds)*time.Second,
+		config.Relationships.CacheSize,
+	)
+
+	userProfileRepository := infrastructure.NewCachedUserProfileRepository(
+		infrastructure.NewRestUserProfileRepository(config.RestConfigs.UsersService.BasePath, usersServiceClient),
+		config.Profiles.CacheTTL(),
+		config.Profiles.CacheSize,
+	)
+
+	postStatsRepository := infrastructure.NewPostStatsRepository(db)
+
+	rankers := ranking.NewSelector(map[string]ranking.Ranker{
+		ranking.SortLatest: ranking.Chronological{},
+		ranking.SortTop: ranking.NewScored(ranking.NewAffinitySignalRepository(
+			ranking.NewPostStatsSignalRepository(postStatsRepository),
+			postStatsRepository,
+		), ranking.Weights{
+			Recency:         config.Ranking.Weights.Recency,
+			RecencyHalfLife: config.Ranking.Weights.RecencyHalfLife(),
+			AuthorAffinity:  config.Ranking.Weights.AuthorAffinity,
+			Likes:           config.Ranking.Weights.Likes,
+			Reposts:         config.Ranking.Weights.Reposts,
+			Replies:         config.Ranking.Weights.Replies,
+		}),
+	}, config.Ranking.DefaultSort, config.Ranking.UserSortsByUser())
+
+	readMarkerRepository := infrastructure.NewReadMarkerRepository(db)
+
+	var rateLimiter ratelimit.Limiter = ratelimit.NewInMemoryLimiter()
+	if config.RateLimit.Redis.Address != "" {
+		rateLimiter = ratelimit.NewRedisLimiter(newRedisClient(config.RateLimit.Redis))
+	}
+
+	timelineService := service.NewTimelineService(timelineRepository, postRepository, restPostRepository, dayTimelineFilledRepository, relationshipRepository)
 
 	return &Dependencies{
-		TimelineService:  timelineService,
-		EventPublisher:   natsPublisher,
-		FollowRepository: followsRepository,
-		PostRepository:   postRepository,
+		TimelineService:        timelineService,
+		EventPublisher:         natsPublisher,
+		EventSubscriber:        natsSubscriber,
+		FollowRepository:       followsRepository,
+		PostRepository:         postRepository,
+		PostStatsRepository:    postStatsRepository,
+		PostCache:              postCache,
+		Rankers:                rankers,
+		RateLimiter:            rateLimiter,
+		ReadMarkerRepository:   readMarkerRepository,
+		RelationshipRepository: relationshipRepository,
+		TimelineRepository:     timelineRepository,
+		UserProfileRepository:  userProfileRepository,
 	}, nil
 }
+
+func restClientConfig(config RestConfig) infrastructure.RestClientConfig {
+	return infrastructure.RestClientConfig{
+		Timeout:            time.Duration(config.Timeout) * time.Millisecond,
+		Retries:            config.Retries,
+		RetryWait:          time.Duration(config.RetryWaitMs) * time.Millisecond,
+		RetryMaxWait:       time.Duration(config.RetryMaxWaitMs) * time.Millisecond,
+		BreakerFailures:    config.BreakerFailures,
+		BreakerOpenTimeout: time.Duration(config.BreakerOpenMs) * time.Millisecond,
+	}
+}
+
+func postCacheStore(config PostCache) infrastructure.PostCacheStore {
+	if config.Redis.Address != "" {
+		return infrastructure.NewRedisPostCache(newRedisClient(config.Redis), config.TTL())
+	}
+	return infrastructure.NewInmemPostCache(config.Size, config.TTL())
+}
+
+func newRedisClient(config Redis) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:     config.Address,
+		Password: config.Password,
+		DB:       config.DB,
+	})
+}
@@ -2,32 +2,55 @@ package application
 
 import (
 	"context"
-	"fmt"
+	"time"
+	"uala-timeline-service/internal/domain"
 	"uala-timeline-service/internal/domain/day_timeline_filled/service"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/libs/events"
 )
 
 type AddPostToUserTimelineCommand struct {
 	UserID string `json:"user_id"`
 	PostID string `json:"post_id"`
+	// Post is only sent when the created post event carried it
+	Post *Post `json:"post,omitempty"`
 }
 
 type AddPostToUserTimeline struct {
 	timelineService service.DayUserTimelineFilledService
+	publisher       events.Publisher
+	payloadMaxAge   time.Duration
 }
 
+// NewAddPostToUserTimeline stores the post carried by the event while its last update is
+// younger than payloadMaxAge, older events may be late enough for the post to have changed
+// so it is fetched again. Zero never trusts the carried post.
 func NewAddPostToUserTimeline(
 	timelineService service.DayUserTimelineFilledService,
+	publisher events.Publisher,
+	payloadMaxAge time.Duration,
 ) *AddPostToUserTimeline {
 	return &AddPostToUserTimeline{
 		timelineService: timelineService,
+		publisher:       publisher,
+		payloadMaxAge:   payloadMaxAge,
 	}
 }
 
 func (g *AddPostToUserTimeline) Exec(ctx context.Context, cmd *AddPostToUserTimelineCommand) error {
-	fmt.Println("Adding post")
-	err := g.timelineService.AddPost(ctx, cmd.PostID, cmd.UserID)
+	var carriedPost *posts.Post
+	if cmd.Post != nil && time.Since(cmd.Post.UpdatedAt) < g.payloadMaxAge {
+		post := toDomainPost(*cmd.Post)
+		carriedPost = &post
+	}
+
+	post, err := g.timelineService.AddPost(ctx, cmd.PostID, cmd.UserID, carriedPost)
 	if err != nil {
-		return err
+		return fromDomainError(err)
+	}
+
+	if post != nil {
+		publishStreamEvent(ctx, g.publisher, domain.NewTimelineStreamPostAddedEvent(cmd.UserID, *post))
 	}
 
 	return nil
@@ -0,0 +1,81 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+	"uala-timeline-service/internal/domain"
+	"uala-timeline-service/internal/domain/posts"
+	mocks_events "uala-timeline-service/libs/events/mocks"
+	"uala-timeline-service/mocks"
+)
+
+func TestAddPostToUserTimeline_Exec(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	text := "hello"
+	mentionedUserID := "user-789"
+	thumbnailUrl := "https://cdn/thumb.png"
+	carriedPost := posts.Post{
+		ID: "post-123",
+		Contents: []posts.Content{
+			{Type: posts.ContentTypeText, Text: &text},
+			{Type: posts.ContentTypeMention, Text: &text, MentionedUserID: &mentionedUserID},
+			{Type: posts.ContentTypeImage, Media: &posts.Media{MimeType: "image/png", Width: 10, Height: 20, ThumbnailUrl: &thumbnailUrl}},
+		},
+		AuthorID:    "author-789",
+		PublishedAt: now.Add(-time.Minute),
+		UpdatedAt:   now,
+	}
+
+	tests := []struct {
+		name          string
+		event         domain.UserTimelineAddPostEvent
+		payloadMaxAge time.Duration
+		expectedPost  *posts.Post
+	}{
+		{
+			name:          "should store the post carried by the event",
+			event:         domain.NewUserTimelineAddPostEvent("user-456", "post-123", &carriedPost),
+			payloadMaxAge: time.Hour,
+			expectedPost:  &carriedPost,
+		},
+		{
+			name:          "should fetch the post when the carried one is too old",
+			event:         domain.NewUserTimelineAddPostEvent("user-456", "post-123", &carriedPost),
+			payloadMaxAge: 0,
+		},
+		{
+			name:          "should fetch the post when the event does not carry it",
+			event:         domain.NewUserTimelineAddPostEvent("user-456", "post-123", nil),
+			payloadMaxAge: time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+			mockPublisher := mocks_events.NewPublisher(t)
+
+			mockTimelineService.On("AddPost", ctx, "post-123", "user-456", tt.expectedPost).Return(&carriedPost, nil).Once()
+			mockPublisher.On("Publish", ctx, mock.AnythingOfType("domain.TimelineStreamEvent")).Return(nil).Once()
+
+			var cmd AddPostToUserTimelineCommand
+			err := json.Unmarshal(tt.event.Payload(), &cmd)
+			assert.NoError(t, err)
+
+			addPostToUserTimeline := NewAddPostToUserTimeline(mockTimelineService, mockPublisher, tt.payloadMaxAge)
+
+			// Act
+			err = addPostToUserTimeline.Exec(ctx, &cmd)
+
+			// Assert
+			assert.NoError(t, err)
+		})
+	}
+}
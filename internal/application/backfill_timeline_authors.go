@@ -0,0 +1,75 @@
+package application
+
+import (
+	"context"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/internal/domain/timeline"
+)
+
+const defaultTimelineAuthorsBatchSize = 100
+
+type BackfillTimelineAuthorsResult struct {
+	Updated int
+	// Skipped posts were deleted on the posts service, post.deleted removes their rows
+	Skipped int
+}
+
+// BackfillTimelineAuthors sets the author of the timeline rows stored before the author_id
+// column was added, unfollows only remove the rows with an author.
+type BackfillTimelineAuthors struct {
+	timelineRepository timeline.TimelineRepository
+	postRepository     posts.PostRepository
+	batchSize          int
+}
+
+func NewBackfillTimelineAuthors(
+	timelineRepository timeline.TimelineRepository,
+	postRepository posts.PostRepository,
+	batchSize int,
+) *BackfillTimelineAuthors {
+	if batchSize <= 0 {
+		batchSize = defaultTimelineAuthorsBatchSize
+	}
+	return &BackfillTimelineAuthors{
+		timelineRepository: timelineRepository,
+		postRepository:     postRepository,
+		batchSize:          batchSize,
+	}
+}
+
+// Exec pages the posts without author by post ID, so posts that are skipped are not read
+// again. It can be stopped and run again at any time.
+func (b *BackfillTimelineAuthors) Exec(ctx context.Context) (*BackfillTimelineAuthorsResult, error) {
+	result := &BackfillTimelineAuthorsResult{}
+	afterPostID := ""
+	for {
+		postIDs, err := b.timelineRepository.GetPostIDsWithoutAuthor(ctx, afterPostID, b.batchSize)
+		if err != nil {
+			return result, fromDomainError(err)
+		}
+		if len(postIDs) == 0 {
+			return result, nil
+		}
+
+		found, err := b.postRepository.MGetPosts(ctx, postIDs)
+		if err != nil {
+			return result, fromDomainError(err)
+		}
+		if len(found.UnavailableIDs) > 0 {
+			return result, fromDomainError(posts.ErrUpstreamUnavailable)
+		}
+
+		for _, post := range found.Posts {
+			if post.AuthorID == "" {
+				result.Skipped++
+				continue
+			}
+			if err := b.timelineRepository.SetPostAuthor(ctx, post.ID, post.AuthorID); err != nil {
+				return result, fromDomainError(err)
+			}
+			result.Updated++
+		}
+		result.Skipped += len(found.NotFoundIDs)
+		afterPostID = postIDs[len(postIDs)-1]
+	}
+}
@@ -0,0 +1,81 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/mocks"
+)
+
+func TestBackfillTimelineAuthors_Exec(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		setupMocks     func(mockTimelineRepo *mocks.TimelineRepository, mockPostRepo *mocks.PostRepository)
+		expectedError  error
+		expectedResult *BackfillTimelineAuthorsResult
+	}{
+		{
+			name: "should set the author of every page and skip deleted posts",
+			setupMocks: func(mockTimelineRepo *mocks.TimelineRepository, mockPostRepo *mocks.PostRepository) {
+				mockTimelineRepo.On("GetPostIDsWithoutAuthor", ctx, "", 2).Return([]string{"post-1", "post-2"}, nil).Once()
+				mockPostRepo.On("MGetPosts", ctx, []string{"post-1", "post-2"}).Return(&posts.MGetPostsResult{
+					Posts:       []posts.Post{{ID: "post-1", AuthorID: "author-789"}},
+					NotFoundIDs: []string{"post-2"},
+				}, nil).Once()
+				mockTimelineRepo.On("SetPostAuthor", ctx, "post-1", "author-789").Return(nil).Once()
+				mockTimelineRepo.On("GetPostIDsWithoutAuthor", ctx, "post-2", 2).Return([]string{"post-3"}, nil).Once()
+				mockPostRepo.On("MGetPosts", ctx, []string{"post-3"}).Return(&posts.MGetPostsResult{
+					Posts: []posts.Post{{ID: "post-3", AuthorID: "author-42"}},
+				}, nil).Once()
+				mockTimelineRepo.On("SetPostAuthor", ctx, "post-3", "author-42").Return(nil).Once()
+				mockTimelineRepo.On("GetPostIDsWithoutAuthor", ctx, "post-3", 2).Return([]string{}, nil).Once()
+			},
+			expectedResult: &BackfillTimelineAuthorsResult{Updated: 2, Skipped: 1},
+		},
+		{
+			name: "should stop when posts are unavailable",
+			setupMocks: func(mockTimelineRepo *mocks.TimelineRepository, mockPostRepo *mocks.PostRepository) {
+				mockTimelineRepo.On("GetPostIDsWithoutAuthor", ctx, "", 2).Return([]string{"post-1"}, nil).Once()
+				mockPostRepo.On("MGetPosts", ctx, []string{"post-1"}).Return(&posts.MGetPostsResult{
+					UnavailableIDs: []string{"post-1"},
+				}, nil).Once()
+			},
+			expectedError:  errors.New("posts service unavailable"),
+			expectedResult: &BackfillTimelineAuthorsResult{},
+		},
+		{
+			name: "should return error when timeline repository fails",
+			setupMocks: func(mockTimelineRepo *mocks.TimelineRepository, mockPostRepo *mocks.PostRepository) {
+				mockTimelineRepo.On("GetPostIDsWithoutAuthor", ctx, "", 2).Return(nil, errors.New("pg error")).Once()
+			},
+			expectedError:  errors.New("pg error"),
+			expectedResult: &BackfillTimelineAuthorsResult{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineRepo := mocks.NewTimelineRepository(t)
+			mockPostRepo := mocks.NewPostRepository(t)
+			tt.setupMocks(mockTimelineRepo, mockPostRepo)
+			backfill := NewBackfillTimelineAuthors(mockTimelineRepo, mockPostRepo, 2)
+
+			// Act
+			result, err := backfill.Exec(ctx)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorContains(t, err, tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package application
+
+import (
+	"context"
+	"time"
+	"uala-timeline-service/internal/domain/day_timeline_filled/service"
+	"uala-timeline-service/internal/domain/posts"
+)
+
+var (
+	FollowFieldsAreMandatory = NewValidationError(
+		"follower and followed fields are mandatory",
+		nil,
+		FieldViolation{Field: "follower_id", Message: "is required"},
+		FieldViolation{Field: "followed_id", Message: "is required"},
+	)
+)
+
+type BackfillUserTimelineCommand struct {
+	FollowerID string `json:"follower_id"`
+	FollowedID string `json:"followed_id"`
+}
+
+type BackfillUserTimeline struct {
+	postRepository  posts.PostRepository
+	timelineService service.DayUserTimelineFilledService
+	lookback        time.Duration
+}
+
+func NewBackfillUserTimeline(
+	postRepository posts.PostRepository,
+	timelineService service.DayUserTimelineFilledService,
+	lookback time.Duration,
+) *BackfillUserTimeline {
+	return &BackfillUserTimeline{
+		postRepository:  postRepository,
+		timelineService: timelineService,
+		lookback:        lookback,
+	}
+}
+
+func (b *BackfillUserTimeline) Exec(ctx context.Context, cmd *BackfillUserTimelineCommand) error {
+	if cmd.FollowerID == "" || cmd.FollowedID == "" {
+		return FollowFieldsAreMandatory
+	}
+
+	authorPosts, err := b.postRepository.GetAuthorPosts(ctx, cmd.FollowedID, time.Now().Add(-b.lookback))
+	if err != nil {
+		return fromDomainError(err)
+	}
+
+	return fromDomainError(b.timelineService.BackfillPosts(ctx, cmd.FollowerID, authorPosts))
+}
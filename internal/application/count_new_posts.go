@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"uala-timeline-service/internal/domain/day_timeline_filled/service"
+	"uala-timeline-service/internal/domain/timeline"
+)
+
+const defaultNewPostsCap = 99
+
+type CountNewPostsCommand struct {
+	UserID string
+	// Since is a timeline cursor or an RFC 3339 timestamp
+	Since string
+	// IncludePostIDs is the number of newest post IDs returned with the count
+	IncludePostIDs int
+}
+
+type CountNewPostsResponse struct {
+	Count int `json:"count"`
+	// HasMore is set when there are more new posts than the cap
+	HasMore bool     `json:"has_more"`
+	PostIDs []string `json:"post_ids,omitempty"`
+}
+
+type CountNewPosts struct {
+	timelineService service.DayUserTimelineFilledService
+	cap             int
+	maxPostIDs      int
+}
+
+func NewCountNewPosts(timelineService service.DayUserTimelineFilledService, cap int, maxPostIDs int) *CountNewPosts {
+	if cap <= 0 {
+		cap = defaultNewPostsCap
+	}
+	return &CountNewPosts{
+		timelineService: timelineService,
+		cap:             cap,
+		maxPostIDs:      min(maxPostIDs, cap),
+	}
+}
+
+// Exec counts the posts newer than the since position reading at most cap + 1 rows.
+func (c *CountNewPosts) Exec(ctx context.Context, cmd *CountNewPostsCommand) (*CountNewPostsResponse, error) {
+	filter, err := c.validate(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	newPosts, err := c.timelineService.GetNewPosts(ctx, cmd.UserID, *filter)
+	if err != nil {
+		return nil, fromDomainError(err)
+	}
+
+	response := &CountNewPostsResponse{
+		Count:   min(len(newPosts), c.cap),
+		HasMore: len(newPosts) > c.cap,
+	}
+	for _, newPost := range newPosts[:min(cmd.IncludePostIDs, response.Count)] {
+		response.PostIDs = append(response.PostIDs, newPost.PostID)
+	}
+
+	return response, nil
+}
+
+func (c *CountNewPosts) validate(cmd *CountNewPostsCommand) (*timeline.NewPostsFilter, error) {
+	var violations []FieldViolation
+	filter := &timeline.NewPostsFilter{Limit: c.cap + 1}
+
+	if cmd.UserID == "" {
+		violations = append(violations, FieldViolation{Field: "user_id", Message: "is required"})
+	}
+
+	if cmd.Since == "" {
+		violations = append(violations, FieldViolation{Field: "since", Message: "is required"})
+	} else if since, err := time.Parse(time.RFC3339Nano, cmd.Since); err == nil {
+		filter.Since = since
+	} else if cursor, err := decodeCursor(cmd.Since); err == nil {
+		filter.Since, filter.SinceID = cursor.PublishedAt, cursor.PostID
+	} else {
+		violations = append(violations, FieldViolation{Field: "since", Message: "must be a timeline cursor or an RFC 3339 timestamp"})
+	}
+
+	if cmd.IncludePostIDs < 0 || cmd.IncludePostIDs > c.maxPostIDs {
+		violations = append(violations, FieldViolation{Field: "include_post_ids", Message: fmt.Sprintf("must be a number between 0 and %d", c.maxPostIDs)})
+	}
+
+	if len(violations) > 0 {
+		return nil, NewValidationError("invalid new posts count request", nil, violations...)
+	}
+	return filter, nil
+}
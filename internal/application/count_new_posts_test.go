@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/internal/domain/timeline"
+	"uala-timeline-service/mocks"
+)
+
+func TestCountNewPosts_Exec(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	since := time.Date(2025, 5, 21, 12, 0, 0, 0, time.UTC)
+	newPosts := []timeline.PostTimeline{
+		{PostID: "post-3", PublishedAt: since.Add(3 * time.Minute)},
+		{PostID: "post-2", PublishedAt: since.Add(2 * time.Minute)},
+		{PostID: "post-1", PublishedAt: since.Add(time.Minute)},
+	}
+
+	tests := []struct {
+		name             string
+		cmd              CountNewPostsCommand
+		setupMocks       func(mockTimelineService *mocks.DayUserTimelineFilledService)
+		expectedError    error
+		expectedResponse *CountNewPostsResponse
+	}{
+		{
+			name: "should count posts since a timestamp",
+			cmd:  CountNewPostsCommand{UserID: "user-456", Since: since.Format(time.RFC3339)},
+			setupMocks: func(mockTimelineService *mocks.DayUserTimelineFilledService) {
+				mockTimelineService.On("GetNewPosts", ctx, "user-456", timeline.NewPostsFilter{Since: since, Limit: 3}).Return(newPosts[1:], nil).Once()
+			},
+			expectedResponse: &CountNewPostsResponse{Count: 2},
+		},
+		{
+			name: "should count posts since a cursor and include the newest post ids",
+			cmd:  CountNewPostsCommand{UserID: "user-456", Since: encodeCursor(posts.Post{ID: "post-0", PublishedAt: since}), IncludePostIDs: 1},
+			setupMocks: func(mockTimelineService *mocks.DayUserTimelineFilledService) {
+				mockTimelineService.On("GetNewPosts", ctx, "user-456", timeline.NewPostsFilter{Since: since, SinceID: "post-0", Limit: 3}).Return(newPosts[1:], nil).Once()
+			},
+			expectedResponse: &CountNewPostsResponse{Count: 2, PostIDs: []string{"post-2"}},
+		},
+		{
+			name: "should cap the count",
+			cmd:  CountNewPostsCommand{UserID: "user-456", Since: since.Format(time.RFC3339), IncludePostIDs: 2},
+			setupMocks: func(mockTimelineService *mocks.DayUserTimelineFilledService) {
+				mockTimelineService.On("GetNewPosts", ctx, "user-456", timeline.NewPostsFilter{Since: since, Limit: 3}).Return(newPosts, nil).Once()
+			},
+			expectedResponse: &CountNewPostsResponse{Count: 2, HasMore: true, PostIDs: []string{"post-3", "post-2"}},
+		},
+		{
+			name:          "should reject an invalid since",
+			cmd:           CountNewPostsCommand{UserID: "user-456", Since: "yesterday", IncludePostIDs: 5},
+			setupMocks:    func(mockTimelineService *mocks.DayUserTimelineFilledService) {},
+			expectedError: errors.New("invalid new posts count request"),
+		},
+		{
+			name: "should return error when timeline service fails",
+			cmd:  CountNewPostsCommand{UserID: "user-456", Since: since.Format(time.RFC3339)},
+			setupMocks: func(mockTimelineService *mocks.DayUserTimelineFilledService) {
+				mockTimelineService.On("GetNewPosts", ctx, "user-456", timeline.NewPostsFilter{Since: since, Limit: 3}).Return(nil, errors.New("timeline error")).Once()
+			},
+			expectedError: errors.New("internal error: timeline error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+			tt.setupMocks(mockTimelineService)
+
+			countNewPosts := NewCountNewPosts(mockTimelineService, 2, 2)
+
+			// Act
+			response, err := countNewPosts.Exec(ctx, &tt.cmd)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResponse, response)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package application
+
+import (
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"uala-timeline-service/internal/domain/posts"
+)
+
+// timelineCursor points to the last post returned, the next page starts with the
+// posts older than it.
+type timelineCursor struct {
+	PublishedAt time.Time
+	PostID      string
+}
+
+func encodeCursor(post posts.Post) string {
+	raw := fmt.Sprintf("%d:%s", post.PublishedAt.UnixNano(), post.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(cursor string) (*timelineCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, InvalidCursor
+	}
+
+	publishedAt, postID, found := strings.Cut(string(raw), ":")
+	if !found || postID == "" {
+		return nil, InvalidCursor
+	}
+
+	unixNano, err := strconv.ParseInt(publishedAt, 10, 64)
+	if err != nil {
+		return nil, InvalidCursor
+	}
+
+	return &timelineCursor{
+		PublishedAt: time.Unix(0, unixNano).UTC(),
+		PostID:      postID,
+	}, nil
+}
+
+// isAfter reports whether the post comes after the cursor in newest first order.
+func (c timelineCursor) isAfter(post posts.Post) bool {
+	if post.PublishedAt.Equal(c.PublishedAt) {
+		return post.ID < c.PostID
+	}
+	return post.PublishedAt.Before(c.PublishedAt)
+}
+
+// paginate returns the page of posts after the cursor and the cursor of the next page,
+// empty when there are no more posts. Ranked posts are not sorted by date, so the page
+// starts after the cursor post, and only falls back to the first post older than the
+// cursor when the cursor post is gone.
+func paginate(timelinePosts []posts.Post, cursor *timelineCursor, limit int) ([]posts.Post, string) {
+	start := 0
+	if cursor != nil {
+		start = slices.IndexFunc(timelinePosts, func(post posts.Post) bool {
+			return post.ID == cursor.PostID
+		}) + 1
+		if start == 0 {
+			start = len(timelinePosts)
+			for i, post := range timelinePosts {
+				if cursor.isAfter(post) {
+					start = i
+					break
+				}
+			}
+		}
+	}
+
+	page := timelinePosts[start:]
+	if limit <= 0 || len(page) <= limit {
+		return page, ""
+	}
+
+	page = page[:limit]
+	return page, encodeCursor(page[len(page)-1])
+}
@@ -0,0 +1,74 @@
+package application
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/internal/domain/ranking"
+)
+
+func TestPaginate(t *testing.T) {
+	now := time.Now().UTC()
+	timelinePosts := []posts.Post{
+		{ID: "post-1", PublishedAt: now.Add(-3 * time.Hour)},
+		{ID: "post-3", PublishedAt: now},
+		{ID: "post-2", PublishedAt: now.Add(-time.Hour)},
+		{ID: "post-4", PublishedAt: now},
+	}
+	ranking.SortNewestFirst(timelinePosts)
+
+	// First page
+	page, nextCursor := paginate(timelinePosts, nil, 2)
+	assert.Equal(t, []string{"post-4", "post-3"}, postIDs(page))
+	assert.NotEmpty(t, nextCursor)
+
+	// Last page
+	cursor, err := decodeCursor(nextCursor)
+	assert.NoError(t, err)
+	page, nextCursor = paginate(timelinePosts, cursor, 2)
+	assert.Equal(t, []string{"post-2", "post-1"}, postIDs(page))
+	assert.Empty(t, nextCursor)
+
+	// Without limit
+	page, nextCursor = paginate(timelinePosts, nil, 0)
+	assert.Len(t, page, 4)
+	assert.Empty(t, nextCursor)
+}
+
+func TestPaginate_Ranked(t *testing.T) {
+	now := time.Now().UTC()
+	rankedPosts := []posts.Post{
+		{ID: "post-2", PublishedAt: now.Add(-2 * time.Hour)},
+		{ID: "post-4", PublishedAt: now},
+		{ID: "post-1", PublishedAt: now.Add(-3 * time.Hour)},
+		{ID: "post-3", PublishedAt: now.Add(-time.Hour)},
+	}
+
+	// Next page starts after the cursor post
+	page, nextCursor := paginate(rankedPosts, nil, 2)
+	assert.Equal(t, []string{"post-2", "post-4"}, postIDs(page))
+	cursor, err := decodeCursor(nextCursor)
+	assert.NoError(t, err)
+	page, _ = paginate(rankedPosts, cursor, 2)
+	assert.Equal(t, []string{"post-1", "post-3"}, postIDs(page))
+
+	// Falls back to the first post older than a cursor post that is gone
+	page, _ = paginate(rankedPosts, &timelineCursor{PublishedAt: now.Add(-90 * time.Minute), PostID: "post-deleted"}, 2)
+	assert.Equal(t, []string{"post-2", "post-4"}, postIDs(page))
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	for _, cursor := range []string{"not base64!", "bm8tc2VwYXJhdG9y", "YWJjOnBvc3QtMQ"} {
+		_, err := decodeCursor(cursor)
+		assert.ErrorIs(t, err, InvalidCursor)
+	}
+}
+
+func postIDs(timelinePosts []posts.Post) []string {
+	ids := make([]string, len(timelinePosts))
+	for i, post := range timelinePosts {
+		ids[i] = post.ID
+	}
+	return ids
+}
@@ -1,8 +1,12 @@
 package application
 
 import (
+	"encoding/json"
 	"time"
 	"uala-timeline-service/internal/domain/day_timeline_filled"
+	"uala-timeline-service/internal/domain/post_stats"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/internal/domain/users"
 )
 
 type TimelineFilled struct {
@@ -12,34 +16,124 @@ type TimelineFilled struct {
 }
 
 type Post struct {
-	ID       string    `json:"id"`
-	Contents []Content `json:"contents"`
-	AuthorID string    `json:"author_id"`
+	ID          string    `json:"id"`
+	Contents    []Content `json:"contents"`
+	AuthorID    string    `json:"author_id"`
+	PublishedAt time.Time `json:"published_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	// Edited is set when the post changed after it was published
+	Edited bool `json:"edited"`
+	// Seen is only set on timeline reads, it compares the post with the reader marker
+	Seen *bool `json:"seen,omitempty"`
+	// Author is only set on timeline reads that expand it and find the author profile
+	Author *Author `json:"author,omitempty"`
+	// Stats is only set on timeline reads, when the counters could be read
+	Stats *PostStats `json:"stats,omitempty"`
+}
+
+type PostStats struct {
+	Likes   int `json:"likes"`
+	Reposts int `json:"reposts"`
+	Replies int `json:"replies"`
+}
+
+type Author struct {
+	ID          string  `json:"id"`
+	Username    string  `json:"username"`
+	DisplayName string  `json:"display_name"`
+	AvatarUrl   *string `json:"avatar_url,omitempty"`
+	Verified    bool    `json:"verified"`
 }
 
 type Content struct {
-	Type string  `json:"type"`
-	Text *string `json:"text"`
-	Url  *string `json:"url,omitempty"`
+	Type            string       `json:"type"`
+	Text            *string      `json:"text"`
+	Url             *string      `json:"url,omitempty"`
+	Media           *Media       `json:"media,omitempty"`
+	Preview         *LinkPreview `json:"preview,omitempty"`
+	MentionedUserID *string      `json:"mentioned_user_id,omitempty"`
+	// Data is the content as the posts service sent it, only set on types unknown to the service
+	Data json.RawMessage `json:"data,omitempty"`
 }
 
-func FromDomain(timelineFilled *day_timeline_filled.DayUserTimelineFilled) *TimelineFilled {
-	posts := make([]Post, len(timelineFilled.Posts))
-	for i, post := range timelineFilled.Posts {
-		contents := make([]Content, len(post.Contents))
-		for i, content := range post.Contents {
-			contents[i] = Content{
-				Type: content.Type,
-				Text: content.Text,
-				Url:  content.Url,
+// UnmarshalJSON keeps the contents of unknown types received on the post events, so they
+// are not lost when the post is stored.
+func (c *Content) UnmarshalJSON(data []byte) error {
+	type content Content
+	var decoded content
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*c = Content(decoded)
+	if !posts.IsKnownContentType(c.Type) && c.Data == nil {
+		c.Data = append(json.RawMessage(nil), data...)
+	}
+	return nil
+}
+
+type Media struct {
+	MimeType     string  `json:"mime_type"`
+	Width        int     `json:"width"`
+	Height       int     `json:"height"`
+	DurationMs   int     `json:"duration_ms,omitempty"`
+	ThumbnailUrl *string `json:"thumbnail_url,omitempty"`
+	AltText      *string `json:"alt_text,omitempty"`
+}
+
+type LinkPreview struct {
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+	ImageUrl    *string `json:"image_url,omitempty"`
+	SiteName    *string `json:"site_name,omitempty"`
+}
+
+func toDomainPost(post Post) posts.Post {
+	return posts.Post{
+		ID:          post.ID,
+		Contents:    toDomainContents(post.Contents),
+		AuthorID:    post.AuthorID,
+		PublishedAt: post.PublishedAt,
+		UpdatedAt:   post.UpdatedAt,
+	}
+}
+
+func toDomainContents(contents []Content) []posts.Content {
+	domainContents := make([]posts.Content, len(contents))
+	for i, content := range contents {
+		domainContents[i] = posts.Content{
+			Type:            content.Type,
+			Text:            content.Text,
+			Url:             content.Url,
+			MentionedUserID: content.MentionedUserID,
+			Data:            content.Data,
+		}
+		if content.Media != nil {
+			domainContents[i].Media = &posts.Media{
+				MimeType:     content.Media.MimeType,
+				Width:        content.Media.Width,
+				Height:       content.Media.Height,
+				DurationMs:   content.Media.DurationMs,
+				ThumbnailUrl: content.Media.ThumbnailUrl,
+				AltText:      content.Media.AltText,
 			}
 		}
-		posts[i] = Post{
-			ID:       post.ID,
-			Contents: contents,
-			AuthorID: post.AuthorID,
+		if content.Preview != nil {
+			domainContents[i].Preview = &posts.LinkPreview{
+				Title:       content.Preview.Title,
+				Description: content.Preview.Description,
+				ImageUrl:    content.Preview.ImageUrl,
+				SiteName:    content.Preview.SiteName,
+			}
 		}
 	}
+	return domainContents
+}
+
+func FromDomain(timelineFilled *day_timeline_filled.DayUserTimelineFilled) *TimelineFilled {
+	posts := make([]Post, len(timelineFilled.Posts))
+	for i, post := range timelineFilled.Posts {
+		posts[i] = FromDomainPost(post)
+	}
 
 	return &TimelineFilled{
 		LastUpdate: timelineFilled.LastUpdate,
@@ -47,3 +141,66 @@ func FromDomain(timelineFilled *day_timeline_filled.DayUserTimelineFilled) *Time
 		UserID:     timelineFilled.UserID,
 	}
 }
+
+func FromDomainPost(post posts.Post) Post {
+	contents := make([]Content, len(post.Contents))
+	for i, content := range post.Contents {
+		contents[i] = fromDomainContent(content)
+	}
+
+	return Post{
+		ID:          post.ID,
+		Contents:    contents,
+		AuthorID:    post.AuthorID,
+		PublishedAt: post.PublishedAt,
+		UpdatedAt:   post.UpdatedAt,
+		Edited:      post.Edited(),
+	}
+}
+
+func fromDomainPostStats(stats post_stats.PostStats) *PostStats {
+	return &PostStats{
+		Likes:   stats.Likes,
+		Reposts: stats.Reposts,
+		Replies: stats.Replies,
+	}
+}
+
+func fromDomainUserProfile(profile users.UserProfile) *Author {
+	return &Author{
+		ID:          profile.UserID,
+		Username:    profile.Username,
+		DisplayName: profile.DisplayName,
+		AvatarUrl:   profile.AvatarUrl,
+		Verified:    profile.Verified,
+	}
+}
+
+func fromDomainContent(content posts.Content) Content {
+	dto := Content{
+		Type:            content.Type,
+		Text:            content.Text,
+		Url:             content.Url,
+		MentionedUserID: content.MentionedUserID,
+		Data:            content.Data,
+	}
+	if content.Media != nil {
+		dto.Media = &Media{
+			MimeType:     content.Media.MimeType,
+			Width:        content.Media.Width,
+			Height:       content.Media.Height,
+			DurationMs:   content.Media.DurationMs,
+			ThumbnailUrl: content.Media.ThumbnailUrl,
+			AltText:      content.Media.AltText,
+		}
+	}
+	if content.Preview != nil {
+		dto.Preview = &LinkPreview{
+			Title:       content.Preview.Title,
+			Description: content.Preview.Description,
+			ImageUrl:    content.Preview.ImageUrl,
+			SiteName:    content.Preview.SiteName,
+		}
+	}
+	return dto
+}
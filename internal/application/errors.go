@@ -0,0 +1,138 @@
+package application
+
+import (
+	"errors"
+	"net/http"
+	"uala-timeline-service/internal/domain/follows"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/internal/domain/read_markers"
+	"uala-timeline-service/internal/domain/relationships"
+	"uala-timeline-service/internal/domain/timeline"
+)
+
+const (
+	CodeInvalidArgument     = "INVALID_ARGUMENT"
+	CodeNotFound            = "NOT_FOUND"
+	CodeUpstreamUnavailable = "UPSTREAM_UNAVAILABLE"
+	CodeResourceExhausted   = "RESOURCE_EXHAUSTED"
+	CodeUnauthenticated     = "UNAUTHENTICATED"
+	CodePermissionDenied    = "PERMISSION_DENIED"
+	CodeInternal            = "INTERNAL_ERROR"
+)
+
+type FieldViolation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is returned by the use cases with everything a transport needs to answer the
+// caller. Err keeps the original cause so errors.Is keeps working over it.
+type Error struct {
+	Code       string
+	Status     int
+	Message    string
+	Violations []FieldViolation
+	Err        error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func NewValidationError(message string, cause error, violations ...FieldViolation) *Error {
+	return &Error{
+		Code:       CodeInvalidArgument,
+		Status:     http.StatusBadRequest,
+		Message:    message,
+		Violations: violations,
+		Err:        cause,
+	}
+}
+
+func NewNotFoundError(message string, cause error) *Error {
+	return &Error{
+		Code:    CodeNotFound,
+		Status:  http.StatusNotFound,
+		Message: message,
+		Err:     cause,
+	}
+}
+
+func NewUpstreamError(message string, cause error) *Error {
+	return &Error{
+		Code:    CodeUpstreamUnavailable,
+		Status:  http.StatusServiceUnavailable,
+		Message: message,
+		Err:     cause,
+	}
+}
+
+func NewUnauthenticatedError(message string) *Error {
+	return &Error{
+		Code:    CodeUnauthenticated,
+		Status:  http.StatusUnauthorized,
+		Message: message,
+	}
+}
+
+func NewPermissionDeniedError(message string) *Error {
+	return &Error{
+		Code:    CodePermissionDenied,
+		Status:  http.StatusForbidden,
+		Message: message,
+	}
+}
+
+func NewResourceExhaustedError(message string) *Error {
+	return &Error{
+		Code:    CodeResourceExhausted,
+		Status:  http.StatusTooManyRequests,
+		Message: message,
+	}
+}
+
+func NewInternalError(cause error) *Error {
+	return &Error{
+		Code:    CodeInternal,
+		Status:  http.StatusInternalServerError,
+		Message: "internal error",
+		Err:     cause,
+	}
+}
+
+// IsValidationError reports if the error is an invalid argument, retrying it fails again.
+func IsValidationError(err error) bool {
+	var appErr *Error
+	return errors.As(err, &appErr) && appErr.Code == CodeInvalidArgument
+}
+
+// fromDomainError translates the domain errors into application errors, unknown errors
+// are returned as internal errors.
+func fromDomainError(err error) error {
+	var appErr *Error
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &appErr):
+		return err
+	case errors.Is(err, timeline.ErrUserTimelineNotFound):
+		return NewNotFoundError("user timeline not found", err)
+	case errors.Is(err, read_markers.ErrReadMarkerNotFound):
+		return NewNotFoundError("read marker not found", err)
+	case errors.Is(err, posts.ErrPostNotFound):
+		return NewNotFoundError("post not found", err)
+	case errors.Is(err, posts.ErrUpstreamUnavailable):
+		return NewUpstreamError("posts service unavailable", err)
+	case errors.Is(err, follows.ErrUpstreamUnavailable), errors.Is(err, relationships.ErrUpstreamUnavailable):
+		return NewUpstreamError("followers service unavailable", err)
+	default:
+		return NewInternalError(err)
+	}
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+	"uala-timeline-service/internal/domain/day_timeline_filled"
+	"uala-timeline-service/internal/domain/post_stats"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/internal/domain/read_markers"
+	"uala-timeline-service/internal/domain/users"
+)
+
+// timelineETag builds a validator from the version of every day snapshot in range, the
+// posts in range, the sort and page returned, the read marker, the expanded authors and the
+// post stats, so hidden authors, edits, ranking, pagination, seen flags, profile and counter
+// changes change it as well. Rebuilt and partial reads have no stored versions and a new
+// last_update on every read, their posts stand for the version and the tag is weak.
+func timelineETag(
+	userTimeline *day_timeline_filled.DayUserTimelineFilled,
+	timelineSort string,
+	page []posts.Post,
+	nextCursor string,
+	marker *read_markers.ReadMarker,
+	authors map[string]users.UserProfile,
+	stats map[string]post_stats.PostStats,
+) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "user:%s\n", userTimeline.UserID)
+
+	dayKeys := make([]string, 0, len(userTimeline.DayVersions))
+	for dayKey := range userTimeline.DayVersions {
+		dayKeys = append(dayKeys, dayKey)
+	}
+	sort.Strings(dayKeys)
+	for _, dayKey := range dayKeys {
+		fmt.Fprintf(hash, "day:%s:%d\n", dayKey, userTimeline.DayVersions[dayKey].UnixNano())
+	}
+	for _, post := range userTimeline.Posts {
+		fmt.Fprintf(hash, "in_range:%s:%d\n", post.ID, post.UpdatedAt.UnixNano())
+	}
+
+	fmt.Fprintf(hash, "sort:%s\n", timelineSort)
+	for _, post := range page {
+		fmt.Fprintf(hash, "post:%s:%d\n", post.ID, post.UpdatedAt.UnixNano())
+	}
+	if stats != nil {
+		for _, post := range page {
+			postStats := stats[post.ID]
+			fmt.Fprintf(hash, "stats:%s:%d:%d:%d\n", post.ID, postStats.Likes, postStats.Reposts, postStats.Replies)
+		}
+	}
+	fmt.Fprintf(hash, "next:%s\n", nextCursor)
+	if marker != nil {
+		fmt.Fprintf(hash, "marker:%s:%d\n", marker.PostID, marker.PublishedAt.UnixNano())
+	}
+
+	authorIDs := make([]string, 0, len(authors))
+	for authorID := range authors {
+		authorIDs = append(authorIDs, authorID)
+	}
+	sort.Strings(authorIDs)
+	for _, authorID := range authorIDs {
+		author := authors[authorID]
+		avatarUrl := ""
+		if author.AvatarUrl != nil {
+			avatarUrl = *author.AvatarUrl
+		}
+		fmt.Fprintf(hash, "author:%s:%q:%q:%q:%t\n", authorID, author.Username, author.DisplayName, avatarUrl, author.Verified)
+	}
+
+	etag := `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+	if userTimeline.Source != day_timeline_filled.SourceCache {
+		return "W/" + etag
+	}
+	return etag
+}
+
+// lastModified is the last update of the day snapshots in range. Rebuilt and partial reads
+// have days without stored snapshot, their last update is the time of the read.
+func lastModified(userTimeline *day_timeline_filled.DayUserTimelineFilled) time.Time {
+	if userTimeline.Source != day_timeline_filled.SourceCache || len(userTimeline.DayVersions) == 0 {
+		return userTimeline.LastUpdate
+	}
+	var last time.Time
+	for _, version := range userTimeline.DayVersions {
+		if version.After(last) {
+			last = version
+		}
+	}
+	return last
+}
@@ -3,14 +3,33 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"slices"
+	"strings"
+	"time"
 	"uala-timeline-service/internal/domain/day_timeline_filled"
 	"uala-timeline-service/internal/domain/day_timeline_filled/service"
+	"uala-timeline-service/internal/domain/post_stats"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/internal/domain/ranking"
+	"uala-timeline-service/internal/domain/read_markers"
+	"uala-timeline-service/internal/domain/users"
 )
 
 var (
 	DatesFieldAreMandatory = errors.New("dates fields are mandatory")
+	InvalidCursor          = errors.New("invalid cursor")
 )
 
+const (
+	MaxTimelineLimit     = 100
+	MaxTimelineRangeDays = 31
+)
+
+// ExpandAuthor embeds the author profile in every post of the page.
+const ExpandAuthor = "author"
+
 type GetUserTimelineCommand struct {
 	UserID    string `json:"-"`
 	FromDay   int    `json:"from_day"`
@@ -19,40 +38,265 @@ type GetUserTimelineCommand struct {
 	ToDay     int    `json:"to_day"`
 	ToMonth   int    `json:"to_month"`
 	ToYear    int    `json:"to_year"`
+	// Timezone is an IANA zone name used to compute the day boundaries, UTC by default
+	Timezone string `json:"timezone"`
+	// Limit is the max number of posts returned, every post in range when zero
+	Limit  int    `json:"limit"`
+	Cursor string `json:"cursor"`
+	// DeviceID selects the read marker used to flag seen posts, the furthest one by default
+	DeviceID string `json:"device_id"`
+	// Expand lists the relations embedded in the posts, only author is supported
+	Expand []string `json:"expand"`
+	// Sort is latest or top, the sort set for the user by default
+	Sort string `json:"sort"`
 }
 
 type GetUserTimelineResponse struct {
 	*TimelineFilled
+	NextCursor string `json:"next_cursor,omitempty"`
+	// UnreadCount is the number of posts in range after the read marker
+	UnreadCount int          `json:"unread_count"`
+	Meta        TimelineMeta `json:"meta"`
+	// ETag and LastModified are the validators used by conditional reads
+	ETag         string    `json:"-"`
+	LastModified time.Time `json:"-"`
+}
+
+// TimelineMeta describes how the timeline was served. From and To are the first and last
+// instant of the requested days in Timezone, Source is one of cache, rebuilt or partial and
+// Sort is the order of the posts, latest or top.
+type TimelineMeta struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Timezone string    `json:"timezone"`
+	Source   string    `json:"source"`
+	Sort     string    `json:"sort"`
 }
 
 type GetUserTimeline struct {
-	timelineService service.DayUserTimelineFilledService
+	timelineService       service.DayUserTimelineFilledService
+	readMarkerRepository  read_markers.ReadMarkerRepository
+	userProfileRepository users.UserProfileRepository
+	postStatsRepository   post_stats.PostStatsRepository
+	rankers               *ranking.Selector
 }
 
-func NewGetUserTimeline(timelineService service.DayUserTimelineFilledService) *GetUserTimeline {
+// NewGetUserTimeline takes an optional profile repository, authors are never expanded
+// without one, an optional post stats repository, posts come without stats without one,
+// and an optional ranker selector, timelines are read chronologically without one.
+func NewGetUserTimeline(
+	timelineService service.DayUserTimelineFilledService,
+	readMarkerRepository read_markers.ReadMarkerRepository,
+	userProfileRepository users.UserProfileRepository,
+	postStatsRepository post_stats.PostStatsRepository,
+	rankers *ranking.Selector,
+) *GetUserTimeline {
+	if rankers == nil {
+		rankers = ranking.NewSelector(nil, ranking.SortLatest, nil)
+	}
 	return &GetUserTimeline{
-		timelineService: timelineService,
+		timelineService:       timelineService,
+		readMarkerRepository:  readMarkerRepository,
+		userProfileRepository: userProfileRepository,
+		postStatsRepository:   postStatsRepository,
+		rankers:               rankers,
 	}
 }
 
 func (g *GetUserTimeline) Exec(ctx context.Context, cmd *GetUserTimelineCommand) (*GetUserTimelineResponse, error) {
-	if cmd.ToDay == 0 || cmd.ToMonth == 0 || cmd.ToYear == 0 || cmd.FromDay == 0 || cmd.FromMonth == 0 || cmd.FromYear == 0 {
-		return nil, DatesFieldAreMandatory
+	location, cursor, err := cmd.validate()
+	if err != nil {
+		return nil, err
 	}
-	userTimeline, err := g.timelineService.GetDayUserTimelineFilled(ctx, day_timeline_filled.DayUserTimelineFilledFilter{
+
+	filter := day_timeline_filled.DayUserTimelineFilledFilter{
 		UserID:    cmd.UserID,
 		FromDay:   cmd.FromDay,
 		FromMonth: cmd.FromMonth,
 		FromYear:  cmd.FromYear,
-		ToMonth:   cmd.ToDay,
-		ToYear:    cmd.ToMonth,
-		ToDay:     cmd.ToYear,
-	})
+		ToMonth:   cmd.ToMonth,
+		ToYear:    cmd.ToYear,
+		ToDay:     cmd.ToDay,
+		Location:  location,
+	}
+	userTimeline, err := g.timelineService.GetDayUserTimelineFilled(ctx, filter)
 	if err != nil {
-		return nil, err
+		return nil, fromDomainError(err)
+	}
+
+	marker, err := findReadMarker(ctx, g.readMarkerRepository, cmd.UserID, cmd.DeviceID)
+	if err != nil && !errors.Is(err, read_markers.ErrReadMarkerNotFound) {
+		return nil, fromDomainError(err)
+	}
+
+	timelineSort, timelinePosts := g.rank(ctx, cmd, userTimeline.Posts)
+	page, nextCursor := paginate(timelinePosts, cursor, cmd.Limit)
+	var authors map[string]users.UserProfile
+	if slices.Contains(cmd.Expand, ExpandAuthor) {
+		authors = g.findAuthors(ctx, page)
 	}
+	stats := g.findStats(ctx, page)
+	etag := timelineETag(userTimeline, timelineSort, page, nextCursor, marker, authors, stats)
+	userTimeline.Posts = page
 
+	timelineFilled := FromDomain(userTimeline)
+	for i, post := range page {
+		seen := marker != nil && marker.HasSeen(post)
+		timelineFilled.Posts[i].Seen = &seen
+		if author, ok := authors[post.AuthorID]; ok {
+			timelineFilled.Posts[i].Author = fromDomainUserProfile(author)
+		}
+		if stats != nil {
+			timelineFilled.Posts[i].Stats = fromDomainPostStats(stats[post.ID])
+		}
+	}
+
+	from, to := filter.Range()
 	return &GetUserTimelineResponse{
-		FromDomain(userTimeline),
+		TimelineFilled: timelineFilled,
+		NextCursor:     nextCursor,
+		UnreadCount:    countUnread(timelinePosts, marker),
+		Meta: TimelineMeta{
+			From:     from,
+			To:       to,
+			Timezone: location.String(),
+			Source:   userTimeline.Source,
+			Sort:     timelineSort,
+		},
+		ETag:         etag,
+		LastModified: lastModified(userTimeline),
 	}, nil
 }
+
+// rank orders a copy of the posts with the ranker of the read. The ranking is an extra as
+// well, so the posts are read chronologically when the ranker fails.
+func (g *GetUserTimeline) rank(ctx context.Context, cmd *GetUserTimelineCommand, timelinePosts []posts.Post) (string, []posts.Post) {
+	timelinePosts = append([]posts.Post{}, timelinePosts...)
+	timelineSort, ranker := g.rankers.Select(cmd.UserID, cmd.Sort)
+	rankedPosts, err := ranker.Rank(ctx, cmd.UserID, timelinePosts)
+	if err != nil {
+		log.Err(err).Str("user_id", cmd.UserID).Str("sort", timelineSort).Msg("error ranking timeline, serving it chronologically")
+		ranking.SortNewestFirst(timelinePosts)
+		return ranking.SortLatest, timelinePosts
+	}
+	return timelineSort, rankedPosts
+}
+
+// findAuthors fetches the profiles of the page authors in one call. The profiles are an
+// extra, so the page is served without them when the users service fails.
+func (g *GetUserTimeline) findAuthors(ctx context.Context, page []posts.Post) map[string]users.UserProfile {
+	if g.userProfileRepository == nil || len(page) == 0 {
+		return nil
+	}
+
+	authorIDs := make([]string, 0, len(page))
+	for _, post := range page {
+		if !slices.Contains(authorIDs, post.AuthorID) {
+			authorIDs = append(authorIDs, post.AuthorID)
+		}
+	}
+
+	authors, err := g.userProfileRepository.MGetUserProfiles(ctx, authorIDs)
+	if err != nil {
+		log.Err(err).Int("authors", len(authorIDs)).Msg("error getting author profiles, serving the timeline without them")
+		return nil
+	}
+	return authors
+}
+
+// findStats reads the counters of the page posts, posts without stats have none yet. It
+// returns nil when there is no repository or it fails, the page is served without stats.
+func (g *GetUserTimeline) findStats(ctx context.Context, page []posts.Post) map[string]post_stats.PostStats {
+	if g.postStatsRepository == nil {
+		return nil
+	}
+
+	postIDs := make([]string, len(page))
+	for i, post := range page {
+		postIDs[i] = post.ID
+	}
+	stats, err := g.postStatsRepository.MGetPostStats(ctx, postIDs)
+	if err != nil {
+		log.Err(err).Int("posts", len(postIDs)).Msg("error getting post stats, serving the timeline without them")
+		return nil
+	}
+	return stats
+}
+
+func countUnread(timelinePosts []posts.Post, marker *read_markers.ReadMarker) int {
+	unread := 0
+	for _, post := range timelinePosts {
+		if marker == nil || !marker.HasSeen(post) {
+			unread++
+		}
+	}
+	return unread
+}
+
+// validate checks every field of the command and reports all the violations at once.
+func (cmd *GetUserTimelineCommand) validate() (*time.Location, *timelineCursor, error) {
+	var violations []FieldViolation
+	var cause error
+
+	from, fromOk := validateDate("from", cmd.FromYear, cmd.FromMonth, cmd.FromDay, &violations)
+	to, toOk := validateDate("to", cmd.ToYear, cmd.ToMonth, cmd.ToDay, &violations)
+	if cmd.FromDay == 0 || cmd.FromMonth == 0 || cmd.FromYear == 0 || cmd.ToDay == 0 || cmd.ToMonth == 0 || cmd.ToYear == 0 {
+		cause = DatesFieldAreMandatory
+	}
+	if fromOk && toOk {
+		switch {
+		case to.Before(from):
+			violations = append(violations, FieldViolation{Field: "to", Message: "must not be before from"})
+		case to.Sub(from) >= MaxTimelineRangeDays*24*time.Hour:
+			violations = append(violations, FieldViolation{Field: "to", Message: fmt.Sprintf("range must be at most %d days", MaxTimelineRangeDays)})
+		}
+	}
+
+	if cmd.Limit < 0 || cmd.Limit > MaxTimelineLimit {
+		violations = append(violations, FieldViolation{Field: "limit", Message: fmt.Sprintf("must be a number between 1 and %d", MaxTimelineLimit)})
+	}
+
+	location, err := time.LoadLocation(cmd.Timezone)
+	if err != nil {
+		violations = append(violations, FieldViolation{Field: "timezone", Message: "must be an IANA timezone"})
+	}
+
+	for _, expand := range cmd.Expand {
+		if expand != ExpandAuthor {
+			violations = append(violations, FieldViolation{Field: "expand", Message: fmt.Sprintf("must be one of %s", ExpandAuthor)})
+			break
+		}
+	}
+
+	if cmd.Sort != "" && !ranking.IsKnownSort(cmd.Sort) {
+		violations = append(violations, FieldViolation{Field: "sort", Message: fmt.Sprintf("must be one of %s", strings.Join(ranking.Sorts, ", "))})
+	}
+
+	var cursor *timelineCursor
+	if cmd.Cursor != "" {
+		cursor, err = decodeCursor(cmd.Cursor)
+		if err != nil {
+			violations = append(violations, FieldViolation{Field: "cursor", Message: "is not a valid cursor"})
+		}
+	}
+
+	if len(violations) > 0 {
+		return nil, nil, NewValidationError("invalid timeline request", cause, violations...)
+	}
+	return location, cursor, nil
+}
+
+// validateDate rejects missing parts and dates that do not exist, like February 30.
+func validateDate(field string, year int, month int, day int, violations *[]FieldViolation) (time.Time, bool) {
+	if year == 0 || month == 0 || day == 0 {
+		*violations = append(*violations, FieldViolation{Field: field, Message: "is required"})
+		return time.Time{}, false
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Year() != year || int(date.Month()) != month || date.Day() != day {
+		*violations = append(*violations, FieldViolation{Field: field, Message: "is not a valid date"})
+		return time.Time{}, false
+	}
+	return date, true
+}
@@ -0,0 +1,240 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+	"uala-timeline-service/internal/domain/day_timeline_filled"
+	"uala-timeline-service/internal/domain/post_stats"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/internal/domain/ranking"
+	"uala-timeline-service/internal/domain/read_markers"
+	"uala-timeline-service/internal/domain/users"
+	"uala-timeline-service/mocks"
+)
+
+func TestGetUserTimeline_ExpandAuthor(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	day := time.Date(2025, 5, 21, 10, 0, 0, 0, time.UTC)
+	timelinePosts := []posts.Post{
+		{ID: "post-1", AuthorID: "author-1", PublishedAt: day.Add(time.Hour), UpdatedAt: day.Add(time.Hour)},
+		{ID: "post-2", AuthorID: "author-2", PublishedAt: day.Add(2 * time.Hour), UpdatedAt: day.Add(2 * time.Hour)},
+		{ID: "post-3", AuthorID: "author-1", PublishedAt: day.Add(3 * time.Hour), UpdatedAt: day.Add(3 * time.Hour)},
+	}
+	avatarUrl := "https://cdn/ana.png"
+	ana := users.UserProfile{UserID: "author-1", Username: "ana", DisplayName: "Ana", AvatarUrl: &avatarUrl, Verified: true}
+
+	tests := []struct {
+		name            string
+		expand          []string
+		setupMocks      func(mockUserProfileRepo *mocks.UserProfileRepository)
+		expectedAuthors []*Author
+	}{
+		{
+			name:   "should embed the profiles found, fetching each author once",
+			expand: []string{ExpandAuthor},
+			setupMocks: func(mockUserProfileRepo *mocks.UserProfileRepository) {
+				mockUserProfileRepo.On("MGetUserProfiles", ctx, []string{"author-1", "author-2"}).Return(map[string]users.UserProfile{
+					"author-1": ana,
+				}, nil).Once()
+			},
+			expectedAuthors: []*Author{
+				{ID: "author-1", Username: "ana", DisplayName: "Ana", AvatarUrl: &avatarUrl, Verified: true},
+				nil,
+				{ID: "author-1", Username: "ana", DisplayName: "Ana", AvatarUrl: &avatarUrl, Verified: true},
+			},
+		},
+		{
+			name:   "should serve the timeline without authors when the users service fails",
+			expand: []string{ExpandAuthor},
+			setupMocks: func(mockUserProfileRepo *mocks.UserProfileRepository) {
+				mockUserProfileRepo.On("MGetUserProfiles", ctx, mock.Anything).Return(nil, users.ErrUpstreamUnavailable).Once()
+			},
+			expectedAuthors: []*Author{nil, nil, nil},
+		},
+		{
+			name:            "should not fetch the authors without expand",
+			setupMocks:      func(mockUserProfileRepo *mocks.UserProfileRepository) {},
+			expectedAuthors: []*Author{nil, nil, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+			mockReadMarkerRepo := mocks.NewReadMarkerRepository(t)
+			mockUserProfileRepo := mocks.NewUserProfileRepository(t)
+			mockTimelineService.On("GetDayUserTimelineFilled", ctx, mock.Anything).Return(&day_timeline_filled.DayUserTimelineFilled{
+				UserID: "user-456",
+				Posts:  timelinePosts,
+			}, nil).Once()
+			mockReadMarkerRepo.On("GetLatestReadMarker", ctx, "user-456").Return(nil, read_markers.ErrReadMarkerNotFound).Once()
+			tt.setupMocks(mockUserProfileRepo)
+
+			getUserTimeline := NewGetUserTimeline(mockTimelineService, mockReadMarkerRepo, mockUserProfileRepo, nil, nil)
+
+			// Act
+			response, err := getUserTimeline.Exec(ctx, &GetUserTimelineCommand{
+				UserID: "user-456", FromDay: 21, FromMonth: 5, FromYear: 2025, ToDay: 21, ToMonth: 5, ToYear: 2025,
+				Expand: tt.expand,
+			})
+
+			// Assert
+			assert.NoError(t, err)
+			authors := make([]*Author, len(response.Posts))
+			for i, post := range response.Posts {
+				authors[i] = post.Author
+			}
+			assert.Equal(t, tt.expectedAuthors, authors)
+		})
+	}
+}
+
+func TestGetUserTimeline_Sort(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	now := time.Now().UTC()
+	yesterday := now.Add(-24 * time.Hour)
+	timelinePosts := []posts.Post{
+		{ID: "post-old", AuthorID: "author-1", PublishedAt: now.Add(-2 * time.Hour), UpdatedAt: now.Add(-2 * time.Hour)},
+		{ID: "post-new", AuthorID: "author-2", PublishedAt: now.Add(-time.Minute), UpdatedAt: now.Add(-time.Minute)},
+	}
+
+	tests := []struct {
+		name            string
+		sort            string
+		setupMocks      func(mockSignalRepo *mocks.SignalRepository)
+		expectedSort    string
+		expectedPostIDs []string
+	}{
+		{
+			name:            "should read chronologically by default",
+			setupMocks:      func(mockSignalRepo *mocks.SignalRepository) {},
+			expectedSort:    ranking.SortLatest,
+			expectedPostIDs: []string{"post-new", "post-old"},
+		},
+		{
+			name: "should rank by score on top",
+			sort: ranking.SortTop,
+			setupMocks: func(mockSignalRepo *mocks.SignalRepository) {
+				mockSignalRepo.On("GetSignals", ctx, "user-456", mock.Anything).Return(map[string]ranking.Signals{
+					"post-old": {Likes: 50},
+				}, nil).Once()
+			},
+			expectedSort:    ranking.SortTop,
+			expectedPostIDs: []string{"post-old", "post-new"},
+		},
+		{
+			name: "should read chronologically when the signals fail",
+			sort: ranking.SortTop,
+			setupMocks: func(mockSignalRepo *mocks.SignalRepository) {
+				mockSignalRepo.On("GetSignals", ctx, "user-456", mock.Anything).Return(nil, errors.New("boom")).Once()
+			},
+			expectedSort:    ranking.SortLatest,
+			expectedPostIDs: []string{"post-new", "post-old"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+			mockReadMarkerRepo := mocks.NewReadMarkerRepository(t)
+			mockSignalRepo := mocks.NewSignalRepository(t)
+			mockTimelineService.On("GetDayUserTimelineFilled", ctx, mock.Anything).Return(&day_timeline_filled.DayUserTimelineFilled{
+				UserID: "user-456",
+				Posts:  timelinePosts,
+			}, nil).Once()
+			mockReadMarkerRepo.On("GetLatestReadMarker", ctx, "user-456").Return(nil, read_markers.ErrReadMarkerNotFound).Once()
+			tt.setupMocks(mockSignalRepo)
+			rankers := ranking.NewSelector(map[string]ranking.Ranker{
+				ranking.SortTop: ranking.NewScored(mockSignalRepo, ranking.Weights{Recency: 1, RecencyHalfLife: time.Hour, Likes: 1}),
+			}, ranking.SortLatest, nil)
+
+			getUserTimeline := NewGetUserTimeline(mockTimelineService, mockReadMarkerRepo, nil, nil, rankers)
+
+			// Act
+			response, err := getUserTimeline.Exec(ctx, &GetUserTimelineCommand{
+				UserID: "user-456", FromDay: yesterday.Day(), FromMonth: int(yesterday.Month()), FromYear: yesterday.Year(), ToDay: now.Day(), ToMonth: int(now.Month()), ToYear: now.Year(),
+				Sort: tt.sort,
+			})
+
+			// Assert
+			assert.NoError(t, err)
+			ids := make([]string, len(response.Posts))
+			for i, post := range response.Posts {
+				ids[i] = post.ID
+			}
+			assert.Equal(t, tt.expectedPostIDs, ids)
+			assert.Equal(t, tt.expectedSort, response.Meta.Sort)
+		})
+	}
+}
+
+func TestGetUserTimeline_Stats(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	day := time.Date(2025, 5, 21, 10, 0, 0, 0, time.UTC)
+	timelinePosts := []posts.Post{
+		{ID: "post-1", AuthorID: "author-1", PublishedAt: day.Add(time.Hour), UpdatedAt: day.Add(time.Hour)},
+		{ID: "post-2", AuthorID: "author-1", PublishedAt: day.Add(2 * time.Hour), UpdatedAt: day.Add(2 * time.Hour)},
+	}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(mockPostStatsRepo *mocks.PostStatsRepository)
+		expectedStats []*PostStats
+	}{
+		{
+			name: "should return the counters of every post, zero when never engaged with",
+			setupMocks: func(mockPostStatsRepo *mocks.PostStatsRepository) {
+				mockPostStatsRepo.On("MGetPostStats", ctx, []string{"post-2", "post-1"}).Return(map[string]post_stats.PostStats{
+					"post-1": {PostID: "post-1", Likes: 3, Reposts: 1, Replies: 2},
+				}, nil).Once()
+			},
+			expectedStats: []*PostStats{{}, {Likes: 3, Reposts: 1, Replies: 2}},
+		},
+		{
+			name: "should serve the timeline without stats when they cannot be read",
+			setupMocks: func(mockPostStatsRepo *mocks.PostStatsRepository) {
+				mockPostStatsRepo.On("MGetPostStats", ctx, mock.Anything).Return(nil, errors.New("boom")).Once()
+			},
+			expectedStats: []*PostStats{nil, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+			mockReadMarkerRepo := mocks.NewReadMarkerRepository(t)
+			mockPostStatsRepo := mocks.NewPostStatsRepository(t)
+			mockTimelineService.On("GetDayUserTimelineFilled", ctx, mock.Anything).Return(&day_timeline_filled.DayUserTimelineFilled{
+				UserID: "user-456",
+				Posts:  timelinePosts,
+			}, nil).Once()
+			mockReadMarkerRepo.On("GetLatestReadMarker", ctx, "user-456").Return(nil, read_markers.ErrReadMarkerNotFound).Once()
+			tt.setupMocks(mockPostStatsRepo)
+
+			getUserTimeline := NewGetUserTimeline(mockTimelineService, mockReadMarkerRepo, nil, mockPostStatsRepo, nil)
+
+			// Act
+			response, err := getUserTimeline.Exec(ctx, &GetUserTimelineCommand{
+				UserID: "user-456", FromDay: 21, FromMonth: 5, FromYear: 2025, ToDay: 21, ToMonth: 5, ToYear: 2025,
+			})
+
+			// Assert
+			assert.NoError(t, err)
+			stats := make([]*PostStats, len(response.Posts))
+			for i, post := range response.Posts {
+				stats[i] = post.Stats
+			}
+			assert.Equal(t, tt.expectedStats, stats)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package application
+
+import (
+	"context"
+	"time"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/internal/domain/read_markers"
+)
+
+type SetReadMarkerCommand struct {
+	UserID   string `json:"-"`
+	DeviceID string `json:"device_id"`
+	// Cursor is the cursor of the last post seen, the id of the stream events works too
+	Cursor string `json:"cursor"`
+}
+
+type GetReadMarkerCommand struct {
+	UserID string
+	// DeviceID is optional, the marker of the device that read further is returned without it
+	DeviceID string
+}
+
+type ReadMarkerResponse struct {
+	DeviceID  string    `json:"device_id"`
+	Cursor    string    `json:"cursor"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SetReadMarker struct {
+	readMarkerRepository read_markers.ReadMarkerRepository
+}
+
+func NewSetReadMarker(readMarkerRepository read_markers.ReadMarkerRepository) *SetReadMarker {
+	return &SetReadMarker{
+		readMarkerRepository: readMarkerRepository,
+	}
+}
+
+// Exec moves the marker of the device forward, older positions are ignored so markers
+// sent out of order do not move it back.
+func (s *SetReadMarker) Exec(ctx context.Context, cmd *SetReadMarkerCommand) (*ReadMarkerResponse, error) {
+	var violations []FieldViolation
+	if cmd.UserID == "" {
+		violations = append(violations, FieldViolation{Field: "user_id", Message: "is required"})
+	}
+	if cmd.DeviceID == "" {
+		violations = append(violations, FieldViolation{Field: "device_id", Message: "is required"})
+	}
+	cursor, err := decodeCursor(cmd.Cursor)
+	if err != nil {
+		violations = append(violations, FieldViolation{Field: "cursor", Message: "is not a valid cursor"})
+	}
+	if len(violations) > 0 {
+		return nil, NewValidationError("invalid read marker", nil, violations...)
+	}
+
+	marker, err := s.readMarkerRepository.SaveReadMarker(ctx, read_markers.ReadMarker{
+		UserID:      cmd.UserID,
+		DeviceID:    cmd.DeviceID,
+		PostID:      cursor.PostID,
+		PublishedAt: cursor.PublishedAt,
+		UpdatedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, fromDomainError(err)
+	}
+
+	return toReadMarkerResponse(marker), nil
+}
+
+type GetReadMarker struct {
+	readMarkerRepository read_markers.ReadMarkerRepository
+}
+
+func NewGetReadMarker(readMarkerRepository read_markers.ReadMarkerRepository) *GetReadMarker {
+	return &GetReadMarker{
+		readMarkerRepository: readMarkerRepository,
+	}
+}
+
+func (g *GetReadMarker) Exec(ctx context.Context, cmd *GetReadMarkerCommand) (*ReadMarkerResponse, error) {
+	if cmd.UserID == "" {
+		return nil, NewValidationError("invalid read marker", nil, FieldViolation{Field: "user_id", Message: "is required"})
+	}
+
+	marker, err := findReadMarker(ctx, g.readMarkerRepository, cmd.UserID, cmd.DeviceID)
+	if err != nil {
+		return nil, fromDomainError(err)
+	}
+
+	return toReadMarkerResponse(marker), nil
+}
+
+func findReadMarker(ctx context.Context, readMarkerRepository read_markers.ReadMarkerRepository, userID string, deviceID string) (*read_markers.ReadMarker, error) {
+	if deviceID == "" {
+		return readMarkerRepository.GetLatestReadMarker(ctx, userID)
+	}
+	return readMarkerRepository.GetReadMarker(ctx, userID, deviceID)
+}
+
+func toReadMarkerResponse(marker *read_markers.ReadMarker) *ReadMarkerResponse {
+	return &ReadMarkerResponse{
+		DeviceID:  marker.DeviceID,
+		Cursor:    encodeCursor(posts.Post{ID: marker.PostID, PublishedAt: marker.PublishedAt}),
+		UpdatedAt: marker.UpdatedAt,
+	}
+}
@@ -0,0 +1,203 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+	"uala-timeline-service/internal/domain/day_timeline_filled"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/internal/domain/read_markers"
+	"uala-timeline-service/mocks"
+)
+
+func TestSetReadMarker_Exec(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	seenAt := time.Date(2025, 5, 21, 12, 0, 0, 0, time.UTC)
+	cursor := encodeCursor(posts.Post{ID: "post-123", PublishedAt: seenAt})
+
+	tests := []struct {
+		name             string
+		cmd              SetReadMarkerCommand
+		setupMocks       func(mockReadMarkerRepo *mocks.ReadMarkerRepository)
+		expectedError    error
+		expectedResponse *ReadMarkerResponse
+	}{
+		{
+			name: "should save the read marker of the device",
+			cmd:  SetReadMarkerCommand{UserID: "user-456", DeviceID: "phone", Cursor: cursor},
+			setupMocks: func(mockReadMarkerRepo *mocks.ReadMarkerRepository) {
+				mockReadMarkerRepo.On("SaveReadMarker", ctx, mock.MatchedBy(func(marker read_markers.ReadMarker) bool {
+					return marker.UserID == "user-456" && marker.DeviceID == "phone" && marker.PostID == "post-123" && marker.PublishedAt.Equal(seenAt)
+				})).Return(&read_markers.ReadMarker{UserID: "user-456", DeviceID: "phone", PostID: "post-123", PublishedAt: seenAt, UpdatedAt: seenAt}, nil).Once()
+			},
+			expectedResponse: &ReadMarkerResponse{DeviceID: "phone", Cursor: cursor, UpdatedAt: seenAt},
+		},
+		{
+			name:          "should require device and a valid cursor",
+			cmd:           SetReadMarkerCommand{UserID: "user-456", Cursor: "not a cursor"},
+			setupMocks:    func(mockReadMarkerRepo *mocks.ReadMarkerRepository) {},
+			expectedError: errors.New("invalid read marker"),
+		},
+		{
+			name: "should return error when repository fails",
+			cmd:  SetReadMarkerCommand{UserID: "user-456", DeviceID: "phone", Cursor: cursor},
+			setupMocks: func(mockReadMarkerRepo *mocks.ReadMarkerRepository) {
+				mockReadMarkerRepo.On("SaveReadMarker", ctx, mock.Anything).Return(nil, errors.New("read marker error")).Once()
+			},
+			expectedError: errors.New("internal error: read marker error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockReadMarkerRepo := mocks.NewReadMarkerRepository(t)
+			tt.setupMocks(mockReadMarkerRepo)
+
+			setReadMarker := NewSetReadMarker(mockReadMarkerRepo)
+
+			// Act
+			response, err := setReadMarker.Exec(ctx, &tt.cmd)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResponse, response)
+		})
+	}
+}
+
+func TestGetReadMarker_Exec(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	seenAt := time.Date(2025, 5, 21, 12, 0, 0, 0, time.UTC)
+	marker := &read_markers.ReadMarker{UserID: "user-456", DeviceID: "tablet", PostID: "post-123", PublishedAt: seenAt, UpdatedAt: seenAt}
+
+	tests := []struct {
+		name           string
+		deviceID       string
+		setupMocks     func(mockReadMarkerRepo *mocks.ReadMarkerRepository)
+		expectedError  error
+		expectedDevice string
+	}{
+		{
+			name:     "should return the marker of the device",
+			deviceID: "tablet",
+			setupMocks: func(mockReadMarkerRepo *mocks.ReadMarkerRepository) {
+				mockReadMarkerRepo.On("GetReadMarker", ctx, "user-456", "tablet").Return(marker, nil).Once()
+			},
+			expectedDevice: "tablet",
+		},
+		{
+			name: "should return the furthest marker without device",
+			setupMocks: func(mockReadMarkerRepo *mocks.ReadMarkerRepository) {
+				mockReadMarkerRepo.On("GetLatestReadMarker", ctx, "user-456").Return(marker, nil).Once()
+			},
+			expectedDevice: "tablet",
+		},
+		{
+			name:     "should return not found when the device has no marker",
+			deviceID: "phone",
+			setupMocks: func(mockReadMarkerRepo *mocks.ReadMarkerRepository) {
+				mockReadMarkerRepo.On("GetReadMarker", ctx, "user-456", "phone").Return(nil, read_markers.ErrReadMarkerNotFound).Once()
+			},
+			expectedError: errors.New("read marker not found: read_marker.not_found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockReadMarkerRepo := mocks.NewReadMarkerRepository(t)
+			tt.setupMocks(mockReadMarkerRepo)
+
+			getReadMarker := NewGetReadMarker(mockReadMarkerRepo)
+
+			// Act
+			response, err := getReadMarker.Exec(ctx, &GetReadMarkerCommand{UserID: "user-456", DeviceID: tt.deviceID})
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedDevice, response.DeviceID)
+		})
+	}
+}
+
+func TestGetUserTimeline_ReadMarker(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	day := time.Date(2025, 5, 21, 0, 0, 0, 0, time.UTC)
+	timelinePosts := []posts.Post{
+		{ID: "post-1", AuthorID: "author-789", PublishedAt: day.Add(time.Hour)},
+		{ID: "post-2", AuthorID: "author-789", PublishedAt: day.Add(2 * time.Hour)},
+		{ID: "post-3", AuthorID: "author-789", PublishedAt: day.Add(3 * time.Hour)},
+	}
+
+	tests := []struct {
+		name                string
+		setupMocks          func(mockReadMarkerRepo *mocks.ReadMarkerRepository)
+		expectedSeen        []bool
+		expectedUnreadCount int
+	}{
+		{
+			name: "should flag the posts up to the marker as seen",
+			setupMocks: func(mockReadMarkerRepo *mocks.ReadMarkerRepository) {
+				mockReadMarkerRepo.On("GetLatestReadMarker", ctx, "user-456").Return(&read_markers.ReadMarker{
+					UserID: "user-456", DeviceID: "phone", PostID: "post-2", PublishedAt: day.Add(2 * time.Hour),
+				}, nil).Once()
+			},
+			expectedSeen:        []bool{false, true, true},
+			expectedUnreadCount: 1,
+		},
+		{
+			name: "should flag every post as unseen without marker",
+			setupMocks: func(mockReadMarkerRepo *mocks.ReadMarkerRepository) {
+				mockReadMarkerRepo.On("GetLatestReadMarker", ctx, "user-456").Return(nil, read_markers.ErrReadMarkerNotFound).Once()
+			},
+			expectedSeen:        []bool{false, false, false},
+			expectedUnreadCount: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+			mockReadMarkerRepo := mocks.NewReadMarkerRepository(t)
+			mockTimelineService.On("GetDayUserTimelineFilled", ctx, mock.Anything).Return(&day_timeline_filled.DayUserTimelineFilled{
+				UserID: "user-456",
+				Posts:  timelinePosts,
+			}, nil).Once()
+			tt.setupMocks(mockReadMarkerRepo)
+
+			getUserTimeline := NewGetUserTimeline(mockTimelineService, mockReadMarkerRepo, nil, nil, nil)
+
+			// Act
+			response, err := getUserTimeline.Exec(ctx, &GetUserTimelineCommand{
+				UserID: "user-456", FromDay: 21, FromMonth: 5, FromYear: 2025, ToDay: 21, ToMonth: 5, ToYear: 2025,
+			})
+
+			// Assert
+			assert.NoError(t, err)
+			seen := make([]bool, len(response.Posts))
+			for i, post := range response.Posts {
+				seen[i] = *post.Seen
+			}
+			assert.Equal(t, tt.expectedSeen, seen)
+			assert.Equal(t, tt.expectedUnreadCount, response.UnreadCount)
+		})
+	}
+}
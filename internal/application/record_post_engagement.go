@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+	"time"
+	"uala-timeline-service/internal/domain/post_stats"
+)
+
+// RecordPostEngagementCommand is the payload of the post liked, reposted and replied
+// events. Undo is set when the like or repost is taken back or the reply is deleted.
+type RecordPostEngagementCommand struct {
+	PostID string `json:"post_id"`
+	UserID string `json:"user_id"`
+	// ReplyID is only sent on replies
+	ReplyID string `json:"reply_id"`
+	Undo    bool   `json:"undo"`
+	// At is when the event happened, events delivered out of order are told apart by it.
+	// The time the event is received is used without it
+	At time.Time `json:"at"`
+}
+
+type RecordPostEngagement struct {
+	postStatsRepository post_stats.PostStatsRepository
+	kind                string
+}
+
+// NewRecordPostEngagement records the engagements of kind, one of the post_stats kinds.
+func NewRecordPostEngagement(postStatsRepository post_stats.PostStatsRepository, kind string) *RecordPostEngagement {
+	return &RecordPostEngagement{
+		postStatsRepository: postStatsRepository,
+		kind:                kind,
+	}
+}
+
+func (r *RecordPostEngagement) Exec(ctx context.Context, cmd *RecordPostEngagementCommand) error {
+	var violations []FieldViolation
+	if cmd.PostID == "" {
+		violations = append(violations, FieldViolation{Field: "post_id", Message: "is required"})
+	}
+	if cmd.UserID == "" {
+		violations = append(violations, FieldViolation{Field: "user_id", Message: "is required"})
+	}
+	if r.kind == post_stats.EngagementReply && cmd.ReplyID == "" {
+		violations = append(violations, FieldViolation{Field: "reply_id", Message: "is required"})
+	}
+	if len(violations) > 0 {
+		return NewValidationError("invalid post engagement", nil, violations...)
+	}
+
+	engagement := post_stats.Engagement{
+		PostID: cmd.PostID,
+		Kind:   r.kind,
+		ID:     cmd.UserID,
+		UserID: cmd.UserID,
+		At:     cmd.At.UTC(),
+	}
+	if cmd.At.IsZero() {
+		engagement.At = time.Now().UTC()
+	}
+	if r.kind == post_stats.EngagementReply {
+		engagement.ID = cmd.ReplyID
+	}
+
+	var err error
+	if cmd.Undo {
+		err = r.postStatsRepository.RemoveEngagement(ctx, engagement)
+	} else {
+		err = r.postStatsRepository.AddEngagement(ctx, engagement)
+	}
+	return fromDomainError(err)
+}
@@ -0,0 +1,104 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+	"uala-timeline-service/internal/domain/post_stats"
+	"uala-timeline-service/mocks"
+)
+
+func TestRecordPostEngagement_Exec(t *testing.T) {
+	ctx := context.Background()
+	eventAt := time.Date(2025, 5, 21, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		kind         string
+		cmd          RecordPostEngagementCommand
+		setupMocks   func(mockPostStatsRepo *mocks.PostStatsRepository)
+		expectedCode string
+	}{
+		{
+			name: "should count the like of the user",
+			kind: post_stats.EngagementLike,
+			cmd:  RecordPostEngagementCommand{PostID: "post-123", UserID: "user-456"},
+			setupMocks: func(mockPostStatsRepo *mocks.PostStatsRepository) {
+				mockPostStatsRepo.On("AddEngagement", ctx, mock.MatchedBy(func(engagement post_stats.Engagement) bool {
+					return engagement.PostID == "post-123" && engagement.Kind == post_stats.EngagementLike &&
+						engagement.ID == "user-456" && engagement.UserID == "user-456"
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name: "should uncount the repost taken back",
+			kind: post_stats.EngagementRepost,
+			cmd:  RecordPostEngagementCommand{PostID: "post-123", UserID: "user-456", Undo: true},
+			setupMocks: func(mockPostStatsRepo *mocks.PostStatsRepository) {
+				mockPostStatsRepo.On("RemoveEngagement", ctx, mock.MatchedBy(func(engagement post_stats.Engagement) bool {
+					return engagement.Kind == post_stats.EngagementRepost && engagement.ID == "user-456"
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name: "should take back the like at the event time",
+			kind: post_stats.EngagementLike,
+			cmd:  RecordPostEngagementCommand{PostID: "post-123", UserID: "user-456", Undo: true, At: eventAt},
+			setupMocks: func(mockPostStatsRepo *mocks.PostStatsRepository) {
+				mockPostStatsRepo.On("RemoveEngagement", ctx, mock.MatchedBy(func(engagement post_stats.Engagement) bool {
+					return engagement.ID == "user-456" && engagement.At.Equal(eventAt)
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name: "should count every reply of the user",
+			kind: post_stats.EngagementReply,
+			cmd:  RecordPostEngagementCommand{PostID: "post-123", UserID: "user-456", ReplyID: "post-789"},
+			setupMocks: func(mockPostStatsRepo *mocks.PostStatsRepository) {
+				mockPostStatsRepo.On("AddEngagement", ctx, mock.MatchedBy(func(engagement post_stats.Engagement) bool {
+					return engagement.Kind == post_stats.EngagementReply && engagement.ID == "post-789" && engagement.UserID == "user-456"
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:         "should require the reply of replies",
+			kind:         post_stats.EngagementReply,
+			cmd:          RecordPostEngagementCommand{PostID: "post-123", UserID: "user-456"},
+			setupMocks:   func(mockPostStatsRepo *mocks.PostStatsRepository) {},
+			expectedCode: CodeInvalidArgument,
+		},
+		{
+			name: "should fail when the counters cannot be stored",
+			kind: post_stats.EngagementLike,
+			cmd:  RecordPostEngagementCommand{PostID: "post-123", UserID: "user-456"},
+			setupMocks: func(mockPostStatsRepo *mocks.PostStatsRepository) {
+				mockPostStatsRepo.On("AddEngagement", ctx, mock.Anything).Return(errors.New("boom")).Once()
+			},
+			expectedCode: CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockPostStatsRepo := mocks.NewPostStatsRepository(t)
+			tt.setupMocks(mockPostStatsRepo)
+			recordPostEngagement := NewRecordPostEngagement(mockPostStatsRepo, tt.kind)
+
+			// Act
+			err := recordPostEngagement.Exec(ctx, &tt.cmd)
+
+			// Assert
+			if tt.expectedCode == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var appErr *Error
+			assert.True(t, errors.As(err, &appErr))
+			assert.Equal(t, tt.expectedCode, appErr.Code)
+		})
+	}
+}
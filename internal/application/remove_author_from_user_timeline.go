@@ -0,0 +1,29 @@
+package application
+
+import (
+	"context"
+	"uala-timeline-service/internal/domain/day_timeline_filled/service"
+)
+
+type RemoveAuthorFromUserTimelineCommand struct {
+	FollowerID string `json:"follower_id"`
+	FollowedID string `json:"followed_id"`
+}
+
+type RemoveAuthorFromUserTimeline struct {
+	timelineService service.DayUserTimelineFilledService
+}
+
+func NewRemoveAuthorFromUserTimeline(timelineService service.DayUserTimelineFilledService) *RemoveAuthorFromUserTimeline {
+	return &RemoveAuthorFromUserTimeline{
+		timelineService: timelineService,
+	}
+}
+
+func (r *RemoveAuthorFromUserTimeline) Exec(ctx context.Context, cmd *RemoveAuthorFromUserTimelineCommand) error {
+	if cmd.FollowerID == "" || cmd.FollowedID == "" {
+		return FollowFieldsAreMandatory
+	}
+
+	return r.timelineService.RemoveAuthorPosts(ctx, cmd.FollowerID, cmd.FollowedID)
+}
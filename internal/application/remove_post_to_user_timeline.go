@@ -2,28 +2,36 @@ package application
 
 import (
 	"context"
+	"uala-timeline-service/internal/domain"
 	"uala-timeline-service/internal/domain/day_timeline_filled/service"
+	"uala-timeline-service/libs/events"
 )
 
 type RemovePostToUserTimelineTimeCommand struct {
-	UserID string
-	PostID string
+	UserID string `json:"user_id"`
+	PostID string `json:"post_id"`
 }
 
 type RemovePostToUserTimelineTime struct {
 	timelineService service.DayUserTimelineFilledService
+	publisher       events.Publisher
 }
 
-func NewRemovePostToUserTimelineTime(timelineService service.DayUserTimelineFilledService) *RemovePostToUserTimelineTime {
+func NewRemovePostToUserTimelineTime(timelineService service.DayUserTimelineFilledService, publisher events.Publisher) *RemovePostToUserTimelineTime {
 	return &RemovePostToUserTimelineTime{
 		timelineService: timelineService,
+		publisher:       publisher,
 	}
 }
 
 func (g *RemovePostToUserTimelineTime) Exec(ctx context.Context, cmd *RemovePostToUserTimelineTimeCommand) error {
-	err := g.timelineService.AddPost(ctx, cmd.UserID, cmd.PostID)
+	post, err := g.timelineService.RemovePost(ctx, cmd.PostID, cmd.UserID)
 	if err != nil {
-		return err
+		return fromDomainError(err)
+	}
+
+	if post != nil {
+		publishStreamEvent(ctx, g.publisher, domain.NewTimelineStreamPostRemovedEvent(cmd.UserID, *post))
 	}
 
 	return nil
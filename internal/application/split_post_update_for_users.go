@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/rs/zerolog/log"
 	"sync"
+	"time"
 	"uala-timeline-service/internal/domain"
 	"uala-timeline-service/internal/domain/follows"
 	"uala-timeline-service/internal/domain/posts"
@@ -13,23 +14,90 @@ import (
 type SplitPostUpdateForUsersCommand struct {
 	ID       string `json:"id"`
 	AuthorID string `json:"author_id"`
+	// Contents, PublishedAt and UpdatedAt are only sent by the enriched post created events
+	Contents    []Content  `json:"contents,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// post returns the post carried by the event, nil for the legacy events that only have
+// the IDs. Posts never edited may come without updated_at.
+func (c *SplitPostUpdateForUsersCommand) post() *posts.Post {
+	if c.Contents == nil || c.PublishedAt == nil {
+		return nil
+	}
+	updatedAt := *c.PublishedAt
+	if c.UpdatedAt != nil {
+		updatedAt = *c.UpdatedAt
+	}
+	return &posts.Post{
+		ID:          c.ID,
+		Contents:    toDomainContents(c.Contents),
+		AuthorID:    c.AuthorID,
+		PublishedAt: *c.PublishedAt,
+		UpdatedAt:   updatedAt,
+	}
 }
 
 type SplitPostUpdateForUsers struct {
 	postRepository    posts.PostRepository
 	followsRepository follows.FollowRepository
 	eventPublisher    events.Publisher
+	includeAuthor     bool
+	userEvent         func(userID string, cmd *SplitPostUpdateForUsersCommand) events.Publishable
 }
 
+// NewSplitPostUpdateForUsers fans out created posts, each follower receives an add post event.
 func NewSplitPostUpdateForUsers(
 	postRepository posts.PostRepository,
 	followsRepository follows.FollowRepository,
 	eventPublisher events.Publisher,
+	includeAuthor bool,
+) *SplitPostUpdateForUsers {
+	return &SplitPostUpdateForUsers{
+		postRepository:    postRepository,
+		followsRepository: followsRepository,
+		eventPublisher:    eventPublisher,
+		includeAuthor:     includeAuthor,
+		userEvent: func(userID string, cmd *SplitPostUpdateForUsersCommand) events.Publishable {
+			return domain.NewUserTimelineAddPostEvent(userID, cmd.ID, cmd.post())
+		},
+	}
+}
+
+// NewSplitPostEditForUsers fans out edited posts, each follower receives an update post event.
+func NewSplitPostEditForUsers(
+	postRepository posts.PostRepository,
+	followsRepository follows.FollowRepository,
+	eventPublisher events.Publisher,
+	includeAuthor bool,
+) *SplitPostUpdateForUsers {
+	return &SplitPostUpdateForUsers{
+		postRepository:    postRepository,
+		followsRepository: followsRepository,
+		eventPublisher:    eventPublisher,
+		includeAuthor:     includeAuthor,
+		userEvent: func(userID string, cmd *SplitPostUpdateForUsersCommand) events.Publishable {
+			return domain.NewUserTimelineUpdatePostEvent(userID, cmd.ID)
+		},
+	}
+}
+
+// NewSplitPostDeleteForUsers fans out deleted posts, each follower receives a remove post event.
+func NewSplitPostDeleteForUsers(
+	postRepository posts.PostRepository,
+	followsRepository follows.FollowRepository,
+	eventPublisher events.Publisher,
+	includeAuthor bool,
 ) *SplitPostUpdateForUsers {
 	return &SplitPostUpdateForUsers{
 		postRepository:    postRepository,
 		followsRepository: followsRepository,
 		eventPublisher:    eventPublisher,
+		includeAuthor:     includeAuthor,
+		userEvent: func(userID string, cmd *SplitPostUpdateForUsersCommand) events.Publishable {
+			return domain.NewUserTimelineRemovePostEvent(userID, cmd.ID)
+		},
 	}
 }
 
@@ -39,20 +107,41 @@ func (s *SplitPostUpdateForUsers) Exec(ctx context.Context, cmd *SplitPostUpdate
 		return err
 	}
 
+	recipientIDs := s.recipientIDs(cmd.AuthorID, followerIDs)
+
 	// We do this with goroutines because we will use a best effort approach
 	wg := sync.WaitGroup{}
-	wg.Add(len(followerIDs))
-	for _, followerID := range followerIDs {
-		go func(followerID string) {
+	wg.Add(len(recipientIDs))
+	for _, recipientID := range recipientIDs {
+		go func(recipientID string) {
 			defer wg.Done()
-			err := s.eventPublisher.Publish(context.WithoutCancel(ctx), domain.NewUserTimelineAddPostEvent(followerID, cmd.ID))
+			err := s.eventPublisher.Publish(context.WithoutCancel(ctx), s.userEvent(recipientID, cmd))
 			if err != nil {
-				log.Err(err).Msg("error publishing user-post to add post to timeline")
+				log.Err(err).Msg("error publishing user-post to timeline")
 				// TODO: log error and send to a retries queue to avoid retrying all the users for some fails
 				return
 			}
-		}(followerID)
+		}(recipientID)
 	}
 	wg.Wait()
 	return nil
 }
+
+// recipientIDs returns the followers plus the author when self posts are enabled,
+// without duplicates in case the author follows itself.
+func (s *SplitPostUpdateForUsers) recipientIDs(authorID string, followerIDs []string) []string {
+	recipientIDs := make([]string, 0, len(followerIDs)+1)
+	seen := make(map[string]struct{}, len(followerIDs)+1)
+	if s.includeAuthor && authorID != "" {
+		recipientIDs = append(recipientIDs, authorID)
+		seen[authorID] = struct{}{}
+	}
+	for _, followerID := range followerIDs {
+		if _, ok := seen[followerID]; ok {
+			continue
+		}
+		seen[followerID] = struct{}{}
+		recipientIDs = append(recipientIDs, followerID)
+	}
+	return recipientIDs
+}
@@ -0,0 +1,174 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+	"uala-timeline-service/internal/domain"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/libs/events"
+	mocks_events "uala-timeline-service/libs/events/mocks"
+	"uala-timeline-service/mocks"
+)
+
+func TestSplitPostUpdateForUsers_Exec(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+
+	tests := []struct {
+		name               string
+		includeAuthor      bool
+		followerIDs        []string
+		followersErr       error
+		expectedError      error
+		expectedRecipients []string
+	}{
+		{
+			name:               "should publish an event for every follower",
+			includeAuthor:      false,
+			followerIDs:        []string{"user-1", "user-2"},
+			expectedRecipients: []string{"user-1", "user-2"},
+		},
+		{
+			name:               "should include the author when self posts are enabled",
+			includeAuthor:      true,
+			followerIDs:        []string{"user-1", "user-2"},
+			expectedRecipients: []string{"author-789", "user-1", "user-2"},
+		},
+		{
+			name:               "should not duplicate the author when it follows itself",
+			includeAuthor:      true,
+			followerIDs:        []string{"user-1", "author-789"},
+			expectedRecipients: []string{"author-789", "user-1"},
+		},
+		{
+			name:          "should return error when follows repository fails",
+			includeAuthor: true,
+			followersErr:  errors.New("followers error"),
+			expectedError: errors.New("followers error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockPostRepo := mocks.NewPostRepository(t)
+			mockFollowRepo := mocks.NewFollowRepository(t)
+			mockPublisher := mocks_events.NewPublisher(t)
+
+			mockFollowRepo.On("GetUserFollowerIDs", ctx, "author-789").Return(tt.followerIDs, tt.followersErr).Once()
+
+			mu := sync.Mutex{}
+			var recipients []string
+			if len(tt.expectedRecipients) > 0 {
+				mockPublisher.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					event := args.Get(1).(events.Publishable)
+					assert.Equal(t, domain.UserTimelineAddPostTopic, event.Topic())
+					mu.Lock()
+					recipients = append(recipients, event.(domain.UserTimelineAddPostEvent).UserID)
+					mu.Unlock()
+				}).Return(nil).Times(len(tt.expectedRecipients))
+			}
+
+			splitPostUpdateForUsers := NewSplitPostUpdateForUsers(mockPostRepo, mockFollowRepo, mockPublisher, tt.includeAuthor)
+
+			// Act
+			err := splitPostUpdateForUsers.Exec(ctx, &SplitPostUpdateForUsersCommand{ID: "post-123", AuthorID: "author-789"})
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			sort.Strings(recipients)
+			assert.Equal(t, tt.expectedRecipients, recipients)
+		})
+	}
+}
+
+func TestSplitPostUpdateForUsers_ExecCarriedPost(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	publishedAt := time.Date(2025, 5, 21, 10, 0, 0, 0, time.UTC)
+	updatedAt := publishedAt.Add(time.Minute)
+	text := "hello"
+
+	tests := []struct {
+		name         string
+		cmd          SplitPostUpdateForUsersCommand
+		expectedPost *posts.Post
+	}{
+		{
+			name: "should carry the post of enriched events",
+			cmd: SplitPostUpdateForUsersCommand{
+				ID:          "post-123",
+				AuthorID:    "author-789",
+				Contents:    []Content{{Type: "text", Text: &text}},
+				PublishedAt: &publishedAt,
+				UpdatedAt:   &updatedAt,
+			},
+			expectedPost: &posts.Post{
+				ID:          "post-123",
+				AuthorID:    "author-789",
+				Contents:    []posts.Content{{Type: "text", Text: &text}},
+				PublishedAt: publishedAt,
+				UpdatedAt:   updatedAt,
+			},
+		},
+		{
+			name: "should use the publish date when the post was never updated",
+			cmd: SplitPostUpdateForUsersCommand{
+				ID:          "post-123",
+				AuthorID:    "author-789",
+				Contents:    []Content{{Type: "text", Text: &text}},
+				PublishedAt: &publishedAt,
+			},
+			expectedPost: &posts.Post{
+				ID:          "post-123",
+				AuthorID:    "author-789",
+				Contents:    []posts.Content{{Type: "text", Text: &text}},
+				PublishedAt: publishedAt,
+				UpdatedAt:   publishedAt,
+			},
+		},
+		{
+			name: "should not carry a post for legacy events",
+			cmd: SplitPostUpdateForUsersCommand{
+				ID:       "post-123",
+				AuthorID: "author-789",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockPostRepo := mocks.NewPostRepository(t)
+			mockFollowRepo := mocks.NewFollowRepository(t)
+			mockPublisher := mocks_events.NewPublisher(t)
+
+			mockFollowRepo.On("GetUserFollowerIDs", ctx, "author-789").Return([]string{"user-1"}, nil).Once()
+			var published domain.UserTimelineAddPostEvent
+			mockPublisher.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				published = args.Get(1).(domain.UserTimelineAddPostEvent)
+			}).Return(nil).Once()
+
+			splitPostUpdateForUsers := NewSplitPostUpdateForUsers(mockPostRepo, mockFollowRepo, mockPublisher, false)
+
+			// Act
+			err := splitPostUpdateForUsers.Exec(ctx, &tt.cmd)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, "user-1", published.UserID)
+			assert.Equal(t, tt.expectedPost, published.Post)
+		})
+	}
+}
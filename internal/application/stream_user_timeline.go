@@ -0,0 +1,223 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/rs/zerolog/log"
+	"slices"
+	"sync"
+	"time"
+	"uala-timeline-service/internal/domain"
+	"uala-timeline-service/internal/domain/day_timeline_filled"
+	"uala-timeline-service/internal/domain/day_timeline_filled/service"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/internal/domain/ranking"
+	"uala-timeline-service/internal/domain/relationships"
+	"uala-timeline-service/internal/domain/timeline"
+	"uala-timeline-service/libs/events"
+)
+
+// streamBufferSize is the number of live events kept for a reader, a reader that falls
+// further behind is disconnected and resumes with Last-Event-ID.
+const streamBufferSize = 64
+
+type StreamUserTimelineCommand struct {
+	UserID string
+	// LastEventID is the ID of the last event received, posts added after it are replayed
+	LastEventID string
+}
+
+// StreamEvent is sent to the live readers of a timeline, ID is the cursor of the post.
+type StreamEvent struct {
+	ID   string
+	Type string
+	Post Post
+}
+
+type StreamUserTimeline struct {
+	timelineService        service.DayUserTimelineFilledService
+	relationshipRepository relationships.RelationshipRepository
+	subscriber             events.Subscriber
+}
+
+func NewStreamUserTimeline(
+	timelineService service.DayUserTimelineFilledService,
+	relationshipRepository relationships.RelationshipRepository,
+	subscriber events.Subscriber,
+) *StreamUserTimeline {
+	return &StreamUserTimeline{
+		timelineService:        timelineService,
+		relationshipRepository: relationshipRepository,
+		subscriber:             subscriber,
+	}
+}
+
+// Exec subscribes to the user timeline before replaying the missed posts, so nothing
+// published in between is lost. The channel is closed when the context is done or when
+// the reader falls behind.
+func (s *StreamUserTimeline) Exec(ctx context.Context, cmd *StreamUserTimelineCommand) (<-chan StreamEvent, error) {
+	if cmd.UserID == "" {
+		return nil, NewValidationError("invalid stream request", nil, FieldViolation{Field: "user_id", Message: "is required"})
+	}
+
+	var cursor *timelineCursor
+	if cmd.LastEventID != "" {
+		var err error
+		cursor, err = decodeCursor(cmd.LastEventID)
+		if err != nil {
+			return nil, NewValidationError("invalid stream request", err, FieldViolation{Field: "last_event_id", Message: "is not a valid event id"})
+		}
+	}
+
+	live := make(chan domain.TimelineStreamEvent, streamBufferSize)
+	overflow := make(chan struct{})
+	var overflowOnce sync.Once
+	subscription, err := s.subscriber.Subscribe(domain.TimelineStreamTopic(cmd.UserID)+".*", func(payload []byte) {
+		var event domain.TimelineStreamEvent
+		err := json.Unmarshal(payload, &event)
+		if err != nil {
+			log.Err(err).Str("user_id", cmd.UserID).Msg("error decoding timeline stream event")
+			return
+		}
+
+		select {
+		case live <- event:
+		default:
+			overflowOnce.Do(func() { close(overflow) })
+		}
+	})
+	if err != nil {
+		return nil, NewInternalError(err)
+	}
+
+	missedPosts, err := s.missedPosts(ctx, cmd.UserID, cursor)
+	if err != nil {
+		subscription.Unsubscribe()
+		return nil, fromDomainError(err)
+	}
+
+	out := make(chan StreamEvent)
+	go func() {
+		defer close(out)
+		defer subscription.Unsubscribe()
+
+		replayed := make(map[string]struct{}, len(missedPosts))
+		for _, post := range missedPosts {
+			replayed[post.ID] = struct{}{}
+			if !sendStreamEvent(ctx, out, newStreamEvent(domain.TimelineStreamPostAdded, post)) {
+				return
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-overflow:
+				return
+			case event := <-live:
+				if _, ok := replayed[event.Post.ID]; ok && event.Type == domain.TimelineStreamPostAdded {
+					continue
+				}
+
+				hidden, err := s.isHiddenAuthor(ctx, cmd.UserID, event.Post.AuthorID)
+				if err != nil {
+					log.Err(err).Str("user_id", cmd.UserID).Msg("error checking hidden authors")
+					continue
+				}
+				if hidden {
+					continue
+				}
+
+				if !sendStreamEvent(ctx, out, newStreamEvent(event.Type, event.Post)) {
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// missedPosts returns the posts added after the cursor, oldest first. Only the last
+// MaxTimelineRangeDays days are replayed.
+func (s *StreamUserTimeline) missedPosts(ctx context.Context, userID string, cursor *timelineCursor) ([]posts.Post, error) {
+	if cursor == nil {
+		return nil, nil
+	}
+
+	now := time.Now().UTC()
+	from := cursor.PublishedAt.UTC()
+	if oldest := now.AddDate(0, 0, -(MaxTimelineRangeDays - 1)); from.Before(oldest) {
+		from = oldest
+	}
+
+	userTimeline, err := s.timelineService.GetDayUserTimelineFilled(ctx, day_timeline_filled.DayUserTimelineFilledFilter{
+		UserID:    userID,
+		FromDay:   from.Day(),
+		FromMonth: int(from.Month()),
+		FromYear:  from.Year(),
+		ToDay:     now.Day(),
+		ToMonth:   int(now.Month()),
+		ToYear:    now.Year(),
+	})
+	if err != nil {
+		if errors.Is(err, timeline.ErrUserTimelineNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	missedPosts := make([]posts.Post, 0, len(userTimeline.Posts))
+	for _, post := range userTimeline.Posts {
+		if post.ID == cursor.PostID || cursor.isAfter(post) {
+			continue
+		}
+		missedPosts = append(missedPosts, post)
+	}
+	ranking.SortNewestFirst(missedPosts)
+	slices.Reverse(missedPosts)
+	return missedPosts, nil
+}
+
+func (s *StreamUserTimeline) isHiddenAuthor(ctx context.Context, userID string, authorID string) (bool, error) {
+	blockedAuthorIDs, err := s.relationshipRepository.GetBlockedAuthorIDs(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	mutedAuthorIDs, err := s.relationshipRepository.GetMutedAuthorIDs(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(blockedAuthorIDs, authorID) || slices.Contains(mutedAuthorIDs, authorID), nil
+}
+
+// publishStreamEvent notifies the live readers of the timeline. The change is already stored,
+// a lost notification only delays the readers until they read the timeline again, so it
+// does not fail the message.
+func publishStreamEvent(ctx context.Context, publisher events.Publisher, event domain.TimelineStreamEvent) {
+	err := publisher.Publish(ctx, event)
+	if err != nil {
+		log.Err(err).Str("user_id", event.UserID).Str("post_id", event.Post.ID).Msg("error publishing timeline stream event")
+	}
+}
+
+func newStreamEvent(eventType string, post posts.Post) StreamEvent {
+	return StreamEvent{
+		ID:   encodeCursor(post),
+		Type: eventType,
+		Post: FromDomainPost(post),
+	}
+}
+
+func sendStreamEvent(ctx context.Context, out chan<- StreamEvent, event StreamEvent) bool {
+	select {
+	case out <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
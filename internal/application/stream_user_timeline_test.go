@@ -0,0 +1,163 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strconv"
+	"testing"
+	"time"
+	"uala-timeline-service/internal/domain"
+	"uala-timeline-service/internal/domain/day_timeline_filled"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/libs/events"
+	"uala-timeline-service/mocks"
+)
+
+func TestStreamUserTimeline_Exec(t *testing.T) {
+	// Setup
+	now := time.Now().UTC()
+	cursorPost := posts.Post{ID: "post-cursor", AuthorID: "author-789", PublishedAt: now.Add(-2 * time.Hour)}
+	oldPost := posts.Post{ID: "post-old", AuthorID: "author-789", PublishedAt: now.Add(-3 * time.Hour)}
+	newPost := posts.Post{ID: "post-new", AuthorID: "author-789", PublishedAt: now.Add(-1 * time.Hour)}
+	newerPost := posts.Post{ID: "post-newer", AuthorID: "author-789", PublishedAt: now.Add(-30 * time.Minute)}
+
+	tests := []struct {
+		name            string
+		lastEventID     string
+		setupMocks      func(mockTimelineService *mocks.DayUserTimelineFilledService, mockRelationshipRepo *mocks.RelationshipRepository)
+		livePosts       []posts.Post
+		expectedError   error
+		expectedPostIDs []string
+	}{
+		{
+			name: "should stream live posts",
+			setupMocks: func(mockTimelineService *mocks.DayUserTimelineFilledService, mockRelationshipRepo *mocks.RelationshipRepository) {
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", mock.Anything, "user-456").Return(nil, nil)
+				mockRelationshipRepo.On("GetMutedAuthorIDs", mock.Anything, "user-456").Return(nil, nil)
+			},
+			livePosts:       []posts.Post{newPost, newerPost},
+			expectedPostIDs: []string{"post-new", "post-newer"},
+		},
+		{
+			name:        "should replay missed posts oldest first before live posts",
+			lastEventID: encodeCursor(cursorPost),
+			setupMocks: func(mockTimelineService *mocks.DayUserTimelineFilledService, mockRelationshipRepo *mocks.RelationshipRepository) {
+				mockTimelineService.On("GetDayUserTimelineFilled", mock.Anything, mock.MatchedBy(func(f day_timeline_filled.DayUserTimelineFilledFilter) bool {
+					return f.UserID == "user-456" && f.FromDay == cursorPost.PublishedAt.Day() && f.ToDay == now.Day()
+				})).Return(&day_timeline_filled.DayUserTimelineFilled{
+					UserID: "user-456",
+					Posts:  []posts.Post{newerPost, oldPost, cursorPost, newPost},
+				}, nil).Once()
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", mock.Anything, "user-456").Return(nil, nil)
+				mockRelationshipRepo.On("GetMutedAuthorIDs", mock.Anything, "user-456").Return(nil, nil)
+			},
+			livePosts:       []posts.Post{newerPost, {ID: "post-live", AuthorID: "author-789", PublishedAt: now}},
+			expectedPostIDs: []string{"post-new", "post-newer", "post-live"},
+		},
+		{
+			name: "should skip posts of hidden authors",
+			setupMocks: func(mockTimelineService *mocks.DayUserTimelineFilledService, mockRelationshipRepo *mocks.RelationshipRepository) {
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", mock.Anything, "user-456").Return([]string{"author-blocked"}, nil)
+				mockRelationshipRepo.On("GetMutedAuthorIDs", mock.Anything, "user-456").Return([]string{"author-muted"}, nil)
+			},
+			livePosts: []posts.Post{
+				{ID: "post-blocked", AuthorID: "author-blocked", PublishedAt: now},
+				{ID: "post-muted", AuthorID: "author-muted", PublishedAt: now},
+				newPost,
+			},
+			expectedPostIDs: []string{"post-new"},
+		},
+		{
+			name:        "should reject an invalid last event id",
+			lastEventID: "not a cursor",
+			setupMocks: func(mockTimelineService *mocks.DayUserTimelineFilledService, mockRelationshipRepo *mocks.RelationshipRepository) {
+			},
+			expectedError: errors.New("invalid stream request: invalid cursor"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			broker := events.NewInMemoryBroker()
+			mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+			mockRelationshipRepo := mocks.NewRelationshipRepository(t)
+			tt.setupMocks(mockTimelineService, mockRelationshipRepo)
+
+			streamUserTimeline := NewStreamUserTimeline(mockTimelineService, mockRelationshipRepo, broker)
+
+			// Act
+			stream, err := streamUserTimeline.Exec(ctx, &StreamUserTimelineCommand{UserID: "user-456", LastEventID: tt.lastEventID})
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+				assert.Equal(t, 0, broker.Subscribers())
+				return
+			}
+			assert.NoError(t, err)
+
+			for _, post := range tt.livePosts {
+				assert.NoError(t, broker.Publish(ctx, domain.NewTimelineStreamPostAddedEvent("user-456", post)))
+			}
+			assert.NoError(t, broker.Publish(ctx, domain.NewTimelineStreamPostAddedEvent("user-other", newPost)))
+
+			var postIDs []string
+			for len(postIDs) < len(tt.expectedPostIDs) {
+				select {
+				case event := <-stream:
+					assert.Equal(t, domain.TimelineStreamPostAdded, event.Type)
+					assert.NotEmpty(t, event.ID)
+					postIDs = append(postIDs, event.Post.ID)
+				case <-time.After(time.Second):
+					t.Fatalf("timeout waiting for events, got %v", postIDs)
+				}
+			}
+			assert.Equal(t, tt.expectedPostIDs, postIDs)
+
+			cancel()
+			for range stream {
+			}
+			assert.Equal(t, 0, broker.Subscribers())
+		})
+	}
+}
+
+func TestStreamUserTimeline_SlowReader(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	broker := events.NewInMemoryBroker()
+	mockTimelineService := mocks.NewDayUserTimelineFilledService(t)
+	mockRelationshipRepo := mocks.NewRelationshipRepository(t)
+	mockRelationshipRepo.On("GetBlockedAuthorIDs", mock.Anything, "user-456").Return(nil, nil).Maybe()
+	mockRelationshipRepo.On("GetMutedAuthorIDs", mock.Anything, "user-456").Return(nil, nil).Maybe()
+
+	streamUserTimeline := NewStreamUserTimeline(mockTimelineService, mockRelationshipRepo, broker)
+	stream, err := streamUserTimeline.Exec(ctx, &StreamUserTimelineCommand{UserID: "user-456"})
+	assert.NoError(t, err)
+
+	// Act
+	for i := 0; i <= 2*streamBufferSize+1; i++ {
+		post := posts.Post{ID: "post-" + strconv.Itoa(i), AuthorID: "author-789", PublishedAt: time.Now()}
+		assert.NoError(t, broker.Publish(ctx, domain.NewTimelineStreamPostAddedEvent("user-456", post)))
+	}
+
+	// Assert
+	closed := make(chan struct{})
+	go func() {
+		for range stream {
+		}
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("stream was not closed for a slow reader")
+	}
+	assert.Equal(t, 0, broker.Subscribers())
+}
@@ -0,0 +1,41 @@
+package application
+
+import (
+	"context"
+	"uala-timeline-service/internal/domain"
+	"uala-timeline-service/internal/domain/day_timeline_filled/service"
+	"uala-timeline-service/libs/events"
+)
+
+type UpdatePostInUserTimelineCommand struct {
+	UserID string `json:"user_id"`
+	PostID string `json:"post_id"`
+}
+
+type UpdatePostInUserTimeline struct {
+	timelineService service.DayUserTimelineFilledService
+	publisher       events.Publisher
+}
+
+func NewUpdatePostInUserTimeline(
+	timelineService service.DayUserTimelineFilledService,
+	publisher events.Publisher,
+) *UpdatePostInUserTimeline {
+	return &UpdatePostInUserTimeline{
+		timelineService: timelineService,
+		publisher:       publisher,
+	}
+}
+
+func (u *UpdatePostInUserTimeline) Exec(ctx context.Context, cmd *UpdatePostInUserTimelineCommand) error {
+	post, err := u.timelineService.UpdatePost(ctx, cmd.PostID, cmd.UserID)
+	if err != nil {
+		return fromDomainError(err)
+	}
+
+	if post != nil {
+		publishStreamEvent(ctx, u.publisher, domain.NewTimelineStreamPostUpdatedEvent(cmd.UserID, *post))
+	}
+
+	return nil
+}
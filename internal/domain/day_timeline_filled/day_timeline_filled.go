@@ -12,12 +12,29 @@ type DayUserTimelineFilledRepository interface {
 	AddPosts(ctx context.Context, userID string, post []posts.Post) error
 	UpdatePosts(ctx context.Context, userID string, post *posts.Post) error
 	RemovePost(ctx context.Context, userID string, post *posts.Post) error
+	RemovePosts(ctx context.Context, userID string, posts []posts.Post) error
 }
 
+// Sources of a read timeline.
+const (
+	// SourceCache timelines are read from the stored day snapshots
+	SourceCache = "cache"
+	// SourceRebuilt timelines are built from the timeline rows and the posts service, and stored
+	SourceRebuilt = "rebuilt"
+	// SourcePartial timelines are rebuilt without the posts that could not be fetched, and not stored
+	SourcePartial = "partial"
+)
+
 type DayUserTimelineFilled struct {
 	LastUpdate time.Time
 	Posts      []posts.Post
 	UserID     string
+	// DayVersions is the last update of every stored day snapshot read, keyed by day
+	DayVersions map[string]time.Time
+	// Source is set on reads only
+	Source string
+	// MissingDays are the UTC days of the read without a stored snapshot
+	MissingDays []time.Time
 }
 
 type DayUserTimelineFilledFilter struct {
@@ -29,12 +46,76 @@ type DayUserTimelineFilledFilter struct {
 	ToYear    int
 	ToDay     int
 	Page      int
+	// Location is the zone used to compute the day boundaries, UTC when nil
+	Location *time.Location
+}
+
+// Range returns the first and last instant of the filter days in the filter location.
+func (f DayUserTimelineFilledFilter) Range() (time.Time, time.Time) {
+	location := f.Location
+	if location == nil {
+		location = time.UTC
+	}
+	from := time.Date(f.FromYear, time.Month(f.FromMonth), f.FromDay, 0, 0, 0, 0, location)
+	to := time.Date(f.ToYear, time.Month(f.ToMonth), f.ToDay, 23, 59, 59, 999999999, location)
+	return from, to
+}
+
+// Days returns the UTC days between the filter days, only the first one when the filter
+// has no end day.
+func (f DayUserTimelineFilledFilter) Days() []time.Time {
+	from := time.Date(f.FromYear, time.Month(f.FromMonth), f.FromDay, 0, 0, 0, 0, time.UTC)
+	if f.ToYear == 0 || f.ToMonth == 0 || f.ToDay == 0 {
+		return []time.Time{from}
+	}
+
+	to := time.Date(f.ToYear, time.Month(f.ToMonth), f.ToDay, 0, 0, 0, 0, time.UTC)
+	var days []time.Time
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
+}
+
+// StorageFilter returns a filter with the UTC days that cover the filter range, snapshots
+// are stored by UTC day so they can be shared by readers in any zone.
+func (f DayUserTimelineFilledFilter) StorageFilter() DayUserTimelineFilledFilter {
+	from, to := f.Range()
+	from, to = from.UTC(), to.UTC()
+	return DayUserTimelineFilledFilter{
+		UserID:    f.UserID,
+		FromDay:   from.Day(),
+		FromMonth: int(from.Month()),
+		FromYear:  from.Year(),
+		ToDay:     to.Day(),
+		ToMonth:   int(to.Month()),
+		ToYear:    to.Year(),
+		Page:      f.Page,
+	}
 }
 
 func (t DayUserTimelineFilled) AddPost(post posts.Post) {
 	t.Posts = append(t.Posts, post)
 }
 
+// Between returns a copy of the timeline with the posts published inside the range.
+func (t DayUserTimelineFilled) Between(from time.Time, to time.Time) DayUserTimelineFilled {
+	postsInRange := make([]posts.Post, 0, len(t.Posts))
+	for _, post := range t.Posts {
+		if post.PublishedAt.Before(from) || post.PublishedAt.After(to) {
+			continue
+		}
+		postsInRange = append(postsInRange, post)
+	}
+	return DayUserTimelineFilled{
+		LastUpdate:  t.LastUpdate,
+		Posts:       postsInRange,
+		UserID:      t.UserID,
+		DayVersions: t.DayVersions,
+		Source:      t.Source,
+	}
+}
+
 func CreateDayUserTimelineFilled(userID string, posts []posts.Post) DayUserTimelineFilled {
 	return DayUserTimelineFilled{
 		LastUpdate: time.Now(),
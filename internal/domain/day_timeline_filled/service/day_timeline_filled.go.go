@@ -3,137 +3,388 @@ package service
 import (
 	"context"
 	"errors"
+	"sort"
 	"time"
 	"uala-timeline-service/internal/domain/day_timeline_filled"
 	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/internal/domain/relationships"
 	"uala-timeline-service/internal/domain/timeline"
 )
 
+//go:generate mockery --name=DayUserTimelineFilledService --filename=mocks_day_user_timeline_filled_service.go --output=../../../../mocks --outpkg=mocks
 type DayUserTimelineFilledService interface {
 	GetDayUserTimelineFilled(ctx context.Context, filter day_timeline_filled.DayUserTimelineFilledFilter) (*day_timeline_filled.DayUserTimelineFilled, error)
-	AddPost(ctx context.Context, postID string, userID string) error
-	RemovePost(ctx context.Context, postID string, userID string) error
+	AddPost(ctx context.Context, postID string, userID string, post *posts.Post) (*posts.Post, error)
+	RemovePost(ctx context.Context, postID string, userID string) (*posts.Post, error)
+	BackfillPosts(ctx context.Context, userID string, posts []posts.Post) error
+	RemoveAuthorPosts(ctx context.Context, userID string, authorID string) error
+	UpdatePost(ctx context.Context, postID string, userID string) (*posts.Post, error)
+	GetNewPosts(ctx context.Context, userID string, filter timeline.NewPostsFilter) ([]timeline.PostTimeline, error)
 }
 
 type service struct {
 	timelineRepository timeline.TimelineRepository
 	postRepository     posts.PostRepository
+	// editedPostRepository reads the posts to update, it skips any post cache as the edit
+	// may not have reached the cache of every replica yet
+	editedPostRepository posts.PostRepository
 	// TODO we use a cache separatly because we will take complex actions in the future to use o refresh the cache
 	timelineFilledRepository day_timeline_filled.DayUserTimelineFilledRepository
+	relationshipRepository   relationships.RelationshipRepository
 }
 
 func NewTimelineService(
 	timelineRepository timeline.TimelineRepository,
 	postRepository posts.PostRepository,
+	editedPostRepository posts.PostRepository,
 	timelineFilledRepository day_timeline_filled.DayUserTimelineFilledRepository,
+	relationshipRepository relationships.RelationshipRepository,
 ) DayUserTimelineFilledService {
 	return &service{
 		timelineRepository:       timelineRepository,
 		postRepository:           postRepository,
+		editedPostRepository:     editedPostRepository,
 		timelineFilledRepository: timelineFilledRepository,
+		relationshipRepository:   relationshipRepository,
 	}
 }
 
-func (s service) RemovePost(ctx context.Context, postID string, userID string) error {
-	post, err := s.postRepository.GetPostById(ctx, postID)
+// RemovePost uses the timeline row to locate the day snapshot, the post may already be
+// deleted on the posts service. The row is the only record of the snapshot to clean, so it
+// is deleted last. It returns the removed post, nil when the user did not have it.
+func (s service) RemovePost(ctx context.Context, postID string, userID string) (*posts.Post, error) {
+	userTimeline, err := s.timelineRepository.GetUserPostTimeline(ctx, userID, postID)
 	if err != nil {
-		return err
+		if errors.Is(err, timeline.ErrUserTimelineNotFound) {
+			return nil, nil
+		}
+		return nil, err
 	}
 
-	timelinePost := timeline.CreateTimelinePostFromPost(*post)
-	err = s.timelineRepository.RemovePostFromTimeline(ctx, userID, timelinePost)
+	timelinePost := userTimeline.Posts[0]
+	post := &posts.Post{
+		ID:          timelinePost.PostID,
+		AuthorID:    timelinePost.AuthorID,
+		PublishedAt: timelinePost.PublishedAt,
+	}
+
+	if err := s.timelineFilledRepository.RemovePost(ctx, userID, post); err != nil {
+		return nil, err
+	}
+
+	if err := s.timelineRepository.RemovePostFromTimeline(ctx, userID, timelinePost); err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// RemoveAuthorPosts deletes every post of the author from the day snapshots where those
+// posts were stored and then from the user timeline. The rows are the only record of the
+// snapshots to clean, so they are deleted last.
+func (s service) RemoveAuthorPosts(ctx context.Context, userID string, authorID string) error {
+	authorPosts, err := s.timelineRepository.GetAuthorPostsFromTimeline(ctx, userID, authorID)
 	if err != nil {
 		return err
 	}
 
-	go func(ctx context.Context) {
-		err = s.timelineFilledRepository.RemovePost(ctx, userID, post)
-		if err != nil {
-			return
+	if len(authorPosts) == 0 {
+		return nil
+	}
+
+	dayPosts := make([]posts.Post, len(authorPosts))
+	postIDs := make([]string, len(authorPosts))
+	for i, authorPost := range authorPosts {
+		dayPosts[i] = posts.Post{
+			ID:          authorPost.PostID,
+			AuthorID:    authorPost.AuthorID,
+			PublishedAt: authorPost.PublishedAt,
 		}
-	}(context.WithoutCancel(ctx))
+		postIDs[i] = authorPost.PostID
+	}
 
-	return nil
+	if err := s.timelineFilledRepository.RemovePosts(ctx, userID, dayPosts); err != nil {
+		return err
+	}
+
+	return s.timelineRepository.RemovePostsFromTimeline(ctx, userID, postIDs)
 }
 
 func (s service) GetDayUserTimelineFilled(ctx context.Context, filter day_timeline_filled.DayUserTimelineFilledFilter) (*day_timeline_filled.DayUserTimelineFilled, error) {
+	timelineFilled, err := s.getDayUserTimelineFilled(ctx, filter.StorageFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	// Snapshots are stored by UTC day, posts are bucketed again using the reader zone
+	from, to := filter.Range()
+	timelineFilledInRange := timelineFilled.Between(from, to)
+	return s.filterHiddenAuthors(ctx, filter.UserID, &timelineFilledInRange)
+}
+
+// filterHiddenAuthors drops the posts of authors blocked or muted by the reader. The day
+// snapshots keep every post, so the filter is applied over the returned copy only. When the
+// followers service is down and the reader lists are not cached the read fails, the posts
+// of hidden authors are never shown.
+func (s service) filterHiddenAuthors(ctx context.Context, userID string, timelineFilled *day_timeline_filled.DayUserTimelineFilled) (*day_timeline_filled.DayUserTimelineFilled, error) {
+	hiddenAuthorIDs, err := s.hiddenAuthorIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hiddenAuthorIDs) == 0 {
+		return timelineFilled, nil
+	}
+
+	visiblePosts := make([]posts.Post, 0, len(timelineFilled.Posts))
+	for _, post := range timelineFilled.Posts {
+		if _, hidden := hiddenAuthorIDs[post.AuthorID]; hidden {
+			continue
+		}
+		visiblePosts = append(visiblePosts, post)
+	}
+
+	return &day_timeline_filled.DayUserTimelineFilled{
+		LastUpdate:  timelineFilled.LastUpdate,
+		Posts:       visiblePosts,
+		UserID:      timelineFilled.UserID,
+		DayVersions: timelineFilled.DayVersions,
+		Source:      timelineFilled.Source,
+	}, nil
+}
+
+// hiddenAuthorIDs returns the authors blocked or muted by the reader.
+func (s service) hiddenAuthorIDs(ctx context.Context, userID string) (map[string]struct{}, error) {
+	blockedAuthorIDs, err := s.relationshipRepository.GetBlockedAuthorIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	mutedAuthorIDs, err := s.relationshipRepository.GetMutedAuthorIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	hiddenAuthorIDs := make(map[string]struct{}, len(blockedAuthorIDs)+len(mutedAuthorIDs))
+	for _, authorID := range blockedAuthorIDs {
+		hiddenAuthorIDs[authorID] = struct{}{}
+	}
+	for _, authorID := range mutedAuthorIDs {
+		hiddenAuthorIDs[authorID] = struct{}{}
+	}
+	return hiddenAuthorIDs, nil
+}
+
+// GetNewPosts reads the posts published after the filter position from the timeline rows,
+// the posts of hidden authors are excluded so they are not counted.
+func (s service) GetNewPosts(ctx context.Context, userID string, filter timeline.NewPostsFilter) ([]timeline.PostTimeline, error) {
+	hiddenAuthorIDs, err := s.hiddenAuthorIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	excludedAuthorIDs := append([]string{}, filter.ExcludedAuthorIDs...)
+	for authorID := range hiddenAuthorIDs {
+		excludedAuthorIDs = append(excludedAuthorIDs, authorID)
+	}
+	sort.Strings(excludedAuthorIDs)
+	filter.ExcludedAuthorIDs = excludedAuthorIDs
+
+	return s.timelineRepository.GetNewPosts(ctx, userID, filter)
+}
+
+// getDayUserTimelineFilled reads the stored day snapshots and rebuilds the days without
+// one, every day when the snapshots cannot be read.
+func (s service) getDayUserTimelineFilled(ctx context.Context, filter day_timeline_filled.DayUserTimelineFilledFilter) (*day_timeline_filled.DayUserTimelineFilled, error) {
 	timelineFilled, err := s.timelineFilledRepository.GetDayUserTimelineFilled(ctx, filter)
-	if err == nil {
+	if err != nil {
+		timelineFilled = &day_timeline_filled.DayUserTimelineFilled{
+			UserID:      filter.UserID,
+			MissingDays: filter.Days(),
+		}
+	}
+
+	timelineFilled.Source = day_timeline_filled.SourceCache
+	if len(timelineFilled.MissingDays) == 0 {
 		return timelineFilled, nil
 	}
+	return s.rebuildMissingDays(ctx, timelineFilled)
+}
 
-	timeline, err := s.timelineRepository.GetUserTimeline(ctx, filter.UserID, timeline.TimelineFilter{
-		DateFrom: time.Date(filter.FromYear, time.Month(filter.FromMonth), filter.FromDay, 0, 0, 0, 0, time.UTC),
-		DateTo:   time.Date(filter.ToYear, time.Month(filter.ToMonth), filter.ToDay, 23, 59, 59, 59, time.UTC),
+// rebuildMissingDays adds the posts of the days without snapshot from the timeline rows and
+// the posts service, and stores them. Days without rows keep the cache source. Deleted posts
+// are left out, unavailable ones are omitted from this read only: the snapshots are not
+// stored so the next read fetches them again.
+func (s service) rebuildMissingDays(ctx context.Context, timelineFilled *day_timeline_filled.DayUserTimelineFilled) (*day_timeline_filled.DayUserTimelineFilled, error) {
+	missingDays := make(map[time.Time]struct{}, len(timelineFilled.MissingDays))
+	first, last := timelineFilled.MissingDays[0], timelineFilled.MissingDays[0]
+	for _, day := range timelineFilled.MissingDays {
+		missingDays[day] = struct{}{}
+		if day.Before(first) {
+			first = day
+		}
+		if day.After(last) {
+			last = day
+		}
+	}
+	timelineFilled.MissingDays = nil
+
+	userTimeline, err := s.timelineRepository.GetUserTimeline(ctx, timelineFilled.UserID, timeline.TimelineFilter{
+		DateFrom: first,
+		DateTo:   last.AddDate(0, 0, 1).Add(-time.Nanosecond),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	postIDs := make([]string, len(timeline.Posts))
-	for i, post := range timeline.Posts {
-		postIDs[i] = post.PostID
+	postIDs := make([]string, 0, len(userTimeline.Posts))
+	for _, timelinePost := range userTimeline.Posts {
+		publishedAt := timelinePost.PublishedAt.UTC()
+		day := time.Date(publishedAt.Year(), publishedAt.Month(), publishedAt.Day(), 0, 0, 0, 0, time.UTC)
+		if _, ok := missingDays[day]; ok {
+			postIDs = append(postIDs, timelinePost.PostID)
+		}
+	}
+	if len(postIDs) == 0 {
+		return timelineFilled, nil
 	}
 
-	posts, err := s.postRepository.MGetPosts(ctx, postIDs)
+	result, err := s.postRepository.MGetPosts(ctx, postIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.timelineFilledRepository.AddPosts(ctx, filter.UserID, posts)
+	timelineFilled.Posts = append(timelineFilled.Posts, result.Posts...)
+	timelineFilled.LastUpdate = time.Now()
+	if len(result.UnavailableIDs) > 0 {
+		timelineFilled.Source = day_timeline_filled.SourcePartial
+		return timelineFilled, nil
+	}
+
+	err = s.timelineFilledRepository.AddPosts(ctx, timelineFilled.UserID, result.Posts)
 	if err != nil {
 		return nil, err
 	}
 
-	newTimelineFilled := day_timeline_filled.CreateDayUserTimelineFilled(filter.UserID, posts)
-	return &newTimelineFilled, nil
+	timelineFilled.Source = day_timeline_filled.SourceRebuilt
+	return timelineFilled, nil
 }
 
-func (s service) AddPost(ctx context.Context, postID string, userID string) error {
-	post, err := s.postRepository.GetPostById(ctx, postID)
-	if err != nil {
-		return err
+// AddPost returns the post when it is new on the user timeline and nil when the user
+// already had it, so redelivered messages are not notified twice. The post carried by the
+// event is stored as it is, without it the post is fetched and the ones deleted before the
+// fanout reached the user are skipped.
+func (s service) AddPost(ctx context.Context, postID string, userID string, post *posts.Post) (*posts.Post, error) {
+	if post == nil {
+		var err error
+		post, err = s.postRepository.GetPostById(ctx, postID)
+		if err != nil {
+			if errors.Is(err, posts.ErrPostNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+	}
+
+	added, err := s.addPost(ctx, post, userID)
+	if err != nil || !added {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// BackfillPosts inserts already fetched posts on the user timeline. It is safe to
+// call it several times with the same posts because each one follows the add post flow.
+func (s service) BackfillPosts(ctx context.Context, userID string, posts []posts.Post) error {
+	for _, post := range posts {
+		_, err := s.addPost(ctx, &post, userID)
+		if err != nil {
+			return err
+		}
 	}
 
-	_, err = s.timelineRepository.GetUserPostTimeline(ctx, userID, postID)
+	return nil
+}
+
+func (s service) addPost(ctx context.Context, post *posts.Post, userID string) (bool, error) {
+	added := false
+	_, err := s.timelineRepository.GetUserPostTimeline(ctx, userID, post.ID)
 	if err != nil {
 		if errors.Is(err, timeline.ErrUserTimelineNotFound) {
 			timelinePost := timeline.CreateTimelinePostFromPost(*post)
 			err = s.timelineRepository.AddPostToUserTimeline(ctx, userID, timelinePost)
 			if err != nil {
-				return err
+				return false, err
 			}
+			added = true
+		}
+	}
+
+	_, err = s.upsertDayPost(ctx, post, userID)
+	return added, err
+}
+
+// UpdatePost refreshes the post on the user timeline only when the user already has it,
+// edits never insert posts on timelines. It returns the post when the stored version changed.
+func (s service) UpdatePost(ctx context.Context, postID string, userID string) (*posts.Post, error) {
+	_, err := s.timelineRepository.GetUserPostTimeline(ctx, userID, postID)
+	if err != nil {
+		if errors.Is(err, timeline.ErrUserTimelineNotFound) {
+			return nil, nil
 		}
+		return nil, err
+	}
+
+	post, err := s.editedPostRepository.GetPostById(ctx, postID)
+	if err != nil {
+		// The post deleted event removes it from the timeline
+		if errors.Is(err, posts.ErrPostNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	updated, err := s.upsertDayPost(ctx, post, userID)
+	if err != nil || !updated {
+		return nil, err
 	}
 
+	return post, nil
+}
+
+// upsertDayPost stores the post on its day snapshot keeping the last version by UpdatedAt,
+// it reports whether the snapshot changed.
+func (s service) upsertDayPost(ctx context.Context, post *posts.Post, userID string) (bool, error) {
+	publishedAt := post.PublishedAt.UTC()
 	dayTimeline, err := s.timelineFilledRepository.GetDayUserTimelineFilled(ctx, day_timeline_filled.DayUserTimelineFilledFilter{
 		UserID:    userID,
-		FromDay:   post.PublishedAt.Day(),
-		FromMonth: int(post.PublishedAt.Month()),
-		FromYear:  post.PublishedAt.Year(),
+		FromDay:   publishedAt.Day(),
+		FromMonth: int(publishedAt.Month()),
+		FromYear:  publishedAt.Year(),
 	})
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	for _, dayTimelinePost := range dayTimeline.Posts {
 		if dayTimelinePost.ID == post.ID {
 			//Discard add legacy message
 			if post.UpdatedAt.Before(dayTimelinePost.UpdatedAt) || post.UpdatedAt.Equal(dayTimelinePost.UpdatedAt) {
-				return nil
+				return false, nil
 			}
 			err = s.timelineFilledRepository.UpdatePosts(ctx, userID, post)
 			if err != nil {
-				return err
+				return false, err
 			}
-			return nil
+			return true, nil
 		}
 	}
 
 	err = s.timelineFilledRepository.AddPosts(ctx, userID, []posts.Post{*post})
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	return true, nil
 }
@@ -3,12 +3,14 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"testing"
 	"time"
 	"uala-timeline-service/internal/domain/day_timeline_filled"
 	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/internal/domain/relationships"
 	"uala-timeline-service/internal/domain/timeline"
 	"uala-timeline-service/mocks"
 )
@@ -23,6 +25,7 @@ func TestService_AddPost(t *testing.T) {
 		name                 string
 		postID               string
 		userID               string
+		carriedPost          *posts.Post
 		setupMocks           func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository)
 		expectedError        error
 		expectTimelineRepoOp bool
@@ -65,6 +68,42 @@ func TestService_AddPost(t *testing.T) {
 			expectedError:        nil,
 			expectTimelineRepoOp: true,
 		},
+		{
+			name:   "should add the carried post without fetching it",
+			postID: "post-123",
+			userID: "user-456",
+			carriedPost: &posts.Post{
+				ID:          "post-123",
+				Contents:    []posts.Content{{Type: "text", Text: stringPtr("test content")}},
+				AuthorID:    "author-789",
+				PublishedAt: now,
+				UpdatedAt:   now,
+			},
+			setupMocks: func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				post := posts.Post{
+					ID:          "post-123",
+					Contents:    []posts.Content{{Type: "text", Text: stringPtr("test content")}},
+					AuthorID:    "author-789",
+					PublishedAt: now,
+					UpdatedAt:   now,
+				}
+
+				mockTimelineRepo.On("GetUserPostTimeline", ctx, "user-456", "post-123").Return(nil, timeline.ErrUserTimelineNotFound).Once()
+				mockTimelineRepo.On("AddPostToUserTimeline", ctx, "user-456", timeline.CreateTimelinePostFromPost(post)).Return(nil).Once()
+
+				filter := day_timeline_filled.DayUserTimelineFilledFilter{
+					UserID:    "user-456",
+					FromDay:   now.Day(),
+					FromMonth: int(now.Month()),
+					FromYear:  now.Year(),
+				}
+
+				mockTimelineFilledRepo.On("GetDayUserTimelineFilled", ctx, filter).Return(&day_timeline_filled.DayUserTimelineFilled{UserID: "user-456"}, nil).Once()
+				mockTimelineFilledRepo.On("AddPosts", ctx, "user-456", []posts.Post{post}).Return(nil).Once()
+			},
+			expectedError:        nil,
+			expectTimelineRepoOp: true,
+		},
 		{
 			name:   "should return error when AddPostToUserTimeline fails",
 			postID: "post-123",
@@ -373,6 +412,16 @@ func TestService_AddPost(t *testing.T) {
 			expectedError:        errors.New("post not found"),
 			expectTimelineRepoOp: false,
 		},
+		{
+			name:   "should skip posts deleted before the fanout",
+			postID: "post-123",
+			userID: "user-456",
+			setupMocks: func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				mockPostRepo.On("GetPostById", ctx, "post-123").Return(nil, fmt.Errorf("%w: post-123", posts.ErrPostNotFound)).Once()
+			},
+			expectedError:        nil,
+			expectTimelineRepoOp: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -381,13 +430,14 @@ func TestService_AddPost(t *testing.T) {
 			mockTimelineRepo := mocks.NewTimelineRepository(t)
 			mockPostRepo := mocks.NewPostRepository(t)
 			mockTimelineFilledRepo := mocks.NewDayUserTimelineFilledRepository(t)
+			mockRelationshipRepo := mocks.NewRelationshipRepository(t)
 
 			tt.setupMocks(mockPostRepo, mockTimelineRepo, mockTimelineFilledRepo)
 
-			service := NewTimelineService(mockTimelineRepo, mockPostRepo, mockTimelineFilledRepo)
+			service := NewTimelineService(mockTimelineRepo, mockPostRepo, mockPostRepo, mockTimelineFilledRepo, mockRelationshipRepo)
 
 			// Act
-			err := service.AddPost(ctx, tt.postID, tt.userID)
+			_, err := service.AddPost(ctx, tt.postID, tt.userID, tt.carriedPost)
 
 			// Assert
 			if tt.expectedError != nil {
@@ -417,6 +467,7 @@ func TestService_RemovePost(t *testing.T) {
 		userID               string
 		setupMocks           func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository)
 		expectedError        error
+		expectedPostID       string
 		expectTimelineRepoOp bool
 	}{
 		{
@@ -424,54 +475,90 @@ func TestService_RemovePost(t *testing.T) {
 			postID: "post-123",
 			userID: "user-456",
 			setupMocks: func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				timelinePost := timeline.PostTimeline{
+					PostID:      "post-123",
+					AuthorID:    "author-789",
+					PublishedAt: now,
+				}
+
 				post := &posts.Post{
 					ID:          "post-123",
-					Contents:    []posts.Content{{Type: "text", Text: stringPtr("test content")}},
 					AuthorID:    "author-789",
 					PublishedAt: now,
-					UpdatedAt:   now,
 				}
 
-				timelinePost := timeline.CreateTimelinePostFromPost(*post)
-
-				mockPostRepo.On("GetPostById", ctx, "post-123").Return(post, nil).Once()
+				mockTimelineRepo.On("GetUserPostTimeline", ctx, "user-456", "post-123").Return(&timeline.UserTimeline{
+					UserID: "user-456",
+					Posts:  []timeline.PostTimeline{timelinePost},
+				}, nil).Once()
+				mockTimelineFilledRepo.On("RemovePost", ctx, "user-456", post).Return(nil).Once()
 				mockTimelineRepo.On("RemovePostFromTimeline", ctx, "user-456", timelinePost).Return(nil).Once()
-
-				// We don't need to verify the goroutine call since it's fire-and-forget
-				// But we can set up the expectation anyway
-				mockTimelineFilledRepo.On("RemovePost", mock.Anything, "user-456", post).Return(nil).Maybe()
 			},
 			expectedError:        nil,
+			expectedPostID:       "post-123",
 			expectTimelineRepoOp: true,
 		},
 		{
-			name:   "should return error when post repository fails",
+			name:   "should do nothing when post is not in timeline",
 			postID: "post-123",
 			userID: "user-456",
 			setupMocks: func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
-				expectedErr := errors.New("post not found")
-				mockPostRepo.On("GetPostById", ctx, "post-123").Return(nil, expectedErr).Once()
+				mockTimelineRepo.On("GetUserPostTimeline", ctx, "user-456", "post-123").Return(nil, timeline.ErrUserTimelineNotFound).Once()
 			},
-			expectedError:        errors.New("post not found"),
-			expectTimelineRepoOp: false,
+			expectedError:        nil,
+			expectTimelineRepoOp: true,
 		},
 		{
-			name:   "should return error when timeline repository fails",
+			name:   "should return error when timeline lookup fails",
 			postID: "post-123",
 			userID: "user-456",
 			setupMocks: func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
-				post := &posts.Post{
-					ID:          "post-123",
-					Contents:    []posts.Content{{Type: "text", Text: stringPtr("test content")}},
+				mockTimelineRepo.On("GetUserPostTimeline", ctx, "user-456", "post-123").Return(nil, timeline.ErrUserTimelineInternal).Once()
+			},
+			expectedError:        timeline.ErrUserTimelineInternal,
+			expectTimelineRepoOp: true,
+		},
+		{
+			name:   "should keep the timeline row when the day snapshot removal fails",
+			postID: "post-123",
+			userID: "user-456",
+			setupMocks: func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				timelinePost := timeline.PostTimeline{
+					PostID:      "post-123",
 					AuthorID:    "author-789",
 					PublishedAt: now,
-					UpdatedAt:   now,
 				}
 
-				timelinePost := timeline.CreateTimelinePostFromPost(*post)
+				mockTimelineRepo.On("GetUserPostTimeline", ctx, "user-456", "post-123").Return(&timeline.UserTimeline{
+					UserID: "user-456",
+					Posts:  []timeline.PostTimeline{timelinePost},
+				}, nil).Once()
+				mockTimelineFilledRepo.On("RemovePost", ctx, "user-456", &posts.Post{
+					ID:          "post-123",
+					AuthorID:    "author-789",
+					PublishedAt: now,
+				}).Return(errors.New("dynamo error")).Once()
+			},
+			expectedError:        errors.New("dynamo error"),
+			expectTimelineRepoOp: true,
+		},
+		{
+			name:   "should return error when timeline repository fails",
+			postID: "post-123",
+			userID: "user-456",
+			setupMocks: func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				timelinePost := timeline.PostTimeline{
+					PostID:      "post-123",
+					AuthorID:    "author-789",
+					PublishedAt: now,
+				}
 				expectedErr := errors.New("timeline error")
 
-				mockPostRepo.On("GetPostById", ctx, "post-123").Return(post, nil).Once()
+				mockTimelineRepo.On("GetUserPostTimeline", ctx, "user-456", "post-123").Return(&timeline.UserTimeline{
+					UserID: "user-456",
+					Posts:  []timeline.PostTimeline{timelinePost},
+				}, nil).Once()
+				mockTimelineFilledRepo.On("RemovePost", ctx, "user-456", mock.Anything).Return(nil).Once()
 				mockTimelineRepo.On("RemovePostFromTimeline", ctx, "user-456", timelinePost).Return(expectedErr).Once()
 			},
 			expectedError:        errors.New("timeline error"),
@@ -485,20 +572,25 @@ func TestService_RemovePost(t *testing.T) {
 			mockTimelineRepo := mocks.NewTimelineRepository(t)
 			mockPostRepo := mocks.NewPostRepository(t)
 			mockTimelineFilledRepo := mocks.NewDayUserTimelineFilledRepository(t)
+			mockRelationshipRepo := mocks.NewRelationshipRepository(t)
 
 			tt.setupMocks(mockPostRepo, mockTimelineRepo, mockTimelineFilledRepo)
 
-			service := NewTimelineService(mockTimelineRepo, mockPostRepo, mockTimelineFilledRepo)
+			service := NewTimelineService(mockTimelineRepo, mockPostRepo, mockPostRepo, mockTimelineFilledRepo, mockRelationshipRepo)
 
 			// Act
-			err := service.RemovePost(ctx, tt.postID, tt.userID)
+			removedPost, err := service.RemovePost(ctx, tt.postID, tt.userID)
 
 			// Assert
 			if tt.expectedError != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else if tt.expectedPostID != "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPostID, removedPost.ID)
 			} else {
 				assert.NoError(t, err)
+				assert.Nil(t, removedPost)
 			}
 
 			mockPostRepo.AssertExpectations(t)
@@ -515,11 +607,13 @@ func TestService_GetDayUserTimelineFilled(t *testing.T) {
 	now := time.Now().UTC()
 
 	tests := []struct {
-		name          string
-		filter        day_timeline_filled.DayUserTimelineFilledFilter
-		setupMocks    func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository)
-		expectedError error
-		expectResult  bool
+		name            string
+		filter          day_timeline_filled.DayUserTimelineFilledFilter
+		setupMocks      func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository)
+		expectedError   error
+		expectResult    bool
+		expectedPostIDs []string
+		expectedSource  string
 	}{
 		{
 			name: "should return timeline from repository if exists",
@@ -543,8 +637,9 @@ func TestService_GetDayUserTimelineFilled(t *testing.T) {
 					return f.UserID == "user-456"
 				})).Return(expectedTimeline, nil).Once()
 			},
-			expectedError: nil,
-			expectResult:  true,
+			expectedError:  nil,
+			expectResult:   true,
+			expectedSource: day_timeline_filled.SourceCache,
 		},
 		{
 			name: "should build timeline if not exists in repository",
@@ -571,7 +666,7 @@ func TestService_GetDayUserTimelineFilled(t *testing.T) {
 					},
 				}
 
-				posts := []posts.Post{
+				fetchedPosts := []posts.Post{
 					{
 						ID:          "post-123",
 						Contents:    []posts.Content{{Type: "text", Text: stringPtr("content 1")}},
@@ -598,11 +693,46 @@ func TestService_GetDayUserTimelineFilled(t *testing.T) {
 						f.DateFrom.Year() == timelineFilter.DateFrom.Year()
 				})).Return(userTimeline, nil).Once()
 
-				mockPostRepo.On("MGetPosts", ctx, []string{"post-123", "post-456"}).Return(posts, nil).Once()
-				mockTimelineFilledRepo.On("AddPosts", ctx, "user-456", posts).Return(nil).Once()
+				mockPostRepo.On("MGetPosts", ctx, []string{"post-123", "post-456"}).Return(&posts.MGetPostsResult{Posts: fetchedPosts}, nil).Once()
+				mockTimelineFilledRepo.On("AddPosts", ctx, "user-456", fetchedPosts).Return(nil).Once()
 			},
-			expectedError: nil,
-			expectResult:  true,
+			expectedError:  nil,
+			expectResult:   true,
+			expectedSource: day_timeline_filled.SourceRebuilt,
+		},
+		{
+			name: "should omit unavailable posts without storing the snapshot",
+			filter: day_timeline_filled.DayUserTimelineFilledFilter{
+				UserID:    "user-456",
+				FromDay:   now.Day(),
+				FromMonth: int(now.Month()),
+				FromYear:  now.Year(),
+				ToDay:     now.Day(),
+				ToMonth:   int(now.Month()),
+				ToYear:    now.Year(),
+			},
+			setupMocks: func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				userTimeline := &timeline.UserTimeline{
+					UserID: "user-456",
+					Posts: []timeline.PostTimeline{
+						{PostID: "post-123", PublishedAt: now},
+						{PostID: "post-456", PublishedAt: now},
+						{PostID: "post-789", PublishedAt: now},
+					},
+				}
+
+				mockTimelineFilledRepo.On("GetDayUserTimelineFilled", ctx, mock.Anything).Return(nil, errors.New("not found")).Once()
+				mockTimelineRepo.On("GetUserTimeline", ctx, "user-456", mock.Anything).Return(userTimeline, nil).Once()
+				mockPostRepo.On("MGetPosts", ctx, []string{"post-123", "post-456", "post-789"}).Return(&posts.MGetPostsResult{
+					Posts:          []posts.Post{{ID: "post-123", AuthorID: "author-789", PublishedAt: now, UpdatedAt: now}},
+					NotFoundIDs:    []string{"post-456"},
+					UnavailableIDs: []string{"post-789"},
+				}, nil).Once()
+			},
+			expectedError:   nil,
+			expectResult:    true,
+			expectedSource:  day_timeline_filled.SourcePartial,
+			expectedPostIDs: []string{"post-123"},
 		},
 		{
 			name: "should return error when timeline repository fails",
@@ -679,7 +809,7 @@ func TestService_GetDayUserTimelineFilled(t *testing.T) {
 					},
 				}
 
-				posts := []posts.Post{
+				fetchedPosts := []posts.Post{
 					{
 						ID:          "post-123",
 						Contents:    []posts.Content{{Type: "text", Text: stringPtr("content 1")}},
@@ -697,8 +827,8 @@ func TestService_GetDayUserTimelineFilled(t *testing.T) {
 
 				mockTimelineRepo.On("GetUserTimeline", ctx, "user-456", mock.Anything).Return(userTimeline, nil).Once()
 
-				mockPostRepo.On("MGetPosts", ctx, []string{"post-123"}).Return(posts, nil).Once()
-				mockTimelineFilledRepo.On("AddPosts", ctx, "user-456", posts).Return(expectedErr).Once()
+				mockPostRepo.On("MGetPosts", ctx, []string{"post-123"}).Return(&posts.MGetPostsResult{Posts: fetchedPosts}, nil).Once()
+				mockTimelineFilledRepo.On("AddPosts", ctx, "user-456", fetchedPosts).Return(expectedErr).Once()
 			},
 			expectedError: errors.New("failed to add posts to repository"),
 			expectResult:  false,
@@ -720,19 +850,55 @@ func TestService_GetDayUserTimelineFilled(t *testing.T) {
 					Posts:  []timeline.PostTimeline{}, // Empty posts
 				}
 
-				posts := []posts.Post{} // Empty posts array
-
 				mockTimelineFilledRepo.On("GetDayUserTimelineFilled", ctx, mock.MatchedBy(func(f day_timeline_filled.DayUserTimelineFilledFilter) bool {
 					return f.UserID == "user-456"
 				})).Return(nil, errors.New("not found")).Once()
 
 				mockTimelineRepo.On("GetUserTimeline", ctx, "user-456", mock.Anything).Return(userTimeline, nil).Once()
+			},
+			expectedError:  nil,
+			expectResult:   true,
+			expectedSource: day_timeline_filled.SourceCache,
+		},
+		{
+			name: "should rebuild only the days missing from a partially cached range",
+			filter: day_timeline_filled.DayUserTimelineFilledFilter{
+				UserID:    "user-456",
+				FromDay:   20,
+				FromMonth: 5,
+				FromYear:  2025,
+				ToDay:     21,
+				ToMonth:   5,
+				ToYear:    2025,
+			},
+			setupMocks: func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				cachedDay := time.Date(2025, 5, 20, 12, 0, 0, 0, time.UTC)
+				missingDay := time.Date(2025, 5, 21, 0, 0, 0, 0, time.UTC)
+				missingPost := posts.Post{ID: "post-missing", AuthorID: "author-789", PublishedAt: missingDay.Add(9 * time.Hour)}
+
+				mockTimelineFilledRepo.On("GetDayUserTimelineFilled", ctx, mock.Anything).Return(&day_timeline_filled.DayUserTimelineFilled{
+					UserID:      "user-456",
+					LastUpdate:  cachedDay,
+					Posts:       []posts.Post{{ID: "post-cached", AuthorID: "author-789", PublishedAt: cachedDay}},
+					DayVersions: map[string]time.Time{"2025:5:20": cachedDay},
+					MissingDays: []time.Time{missingDay},
+				}, nil).Once()
+
+				mockTimelineRepo.On("GetUserTimeline", ctx, "user-456", timeline.TimelineFilter{
+					DateFrom: missingDay,
+					DateTo:   missingDay.AddDate(0, 0, 1).Add(-time.Nanosecond),
+				}).Return(&timeline.UserTimeline{
+					UserID: "user-456",
+					Posts:  []timeline.PostTimeline{{PostID: "post-missing", PublishedAt: missingPost.PublishedAt}},
+				}, nil).Once()
 
-				mockPostRepo.On("MGetPosts", ctx, []string{}).Return(posts, nil).Once()
-				mockTimelineFilledRepo.On("AddPosts", ctx, "user-456", posts).Return(nil).Once()
+				mockPostRepo.On("MGetPosts", ctx, []string{"post-missing"}).Return(&posts.MGetPostsResult{Posts: []posts.Post{missingPost}}, nil).Once()
+				mockTimelineFilledRepo.On("AddPosts", ctx, "user-456", []posts.Post{missingPost}).Return(nil).Once()
 			},
-			expectedError: nil,
-			expectResult:  true,
+			expectedError:   nil,
+			expectResult:    true,
+			expectedPostIDs: []string{"post-cached", "post-missing"},
+			expectedSource:  day_timeline_filled.SourceRebuilt,
 		},
 		{
 			name: "should handle timeline with single post",
@@ -753,7 +919,7 @@ func TestService_GetDayUserTimelineFilled(t *testing.T) {
 					},
 				}
 
-				posts := []posts.Post{
+				fetchedPosts := []posts.Post{
 					{
 						ID:          "post-single",
 						Contents:    []posts.Content{{Type: "text", Text: stringPtr("single content")}},
@@ -769,8 +935,8 @@ func TestService_GetDayUserTimelineFilled(t *testing.T) {
 
 				mockTimelineRepo.On("GetUserTimeline", ctx, "user-456", mock.Anything).Return(userTimeline, nil).Once()
 
-				mockPostRepo.On("MGetPosts", ctx, []string{"post-single"}).Return(posts, nil).Once()
-				mockTimelineFilledRepo.On("AddPosts", ctx, "user-456", posts).Return(nil).Once()
+				mockPostRepo.On("MGetPosts", ctx, []string{"post-single"}).Return(&posts.MGetPostsResult{Posts: fetchedPosts}, nil).Once()
+				mockTimelineFilledRepo.On("AddPosts", ctx, "user-456", fetchedPosts).Return(nil).Once()
 			},
 			expectedError: nil,
 			expectResult:  true,
@@ -794,7 +960,7 @@ func TestService_GetDayUserTimelineFilled(t *testing.T) {
 					},
 				}
 
-				posts := []posts.Post{
+				fetchedPosts := []posts.Post{
 					{
 						ID:          "post-jan",
 						Contents:    []posts.Content{{Type: "text", Text: stringPtr("january content")}},
@@ -813,8 +979,8 @@ func TestService_GetDayUserTimelineFilled(t *testing.T) {
 						f.DateTo.Year() == 2024 && f.DateTo.Month() == 1 && f.DateTo.Day() == 31
 				})).Return(userTimeline, nil).Once()
 
-				mockPostRepo.On("MGetPosts", ctx, []string{"post-jan"}).Return(posts, nil).Once()
-				mockTimelineFilledRepo.On("AddPosts", ctx, "user-456", posts).Return(nil).Once()
+				mockPostRepo.On("MGetPosts", ctx, []string{"post-jan"}).Return(&posts.MGetPostsResult{Posts: fetchedPosts}, nil).Once()
+				mockTimelineFilledRepo.On("AddPosts", ctx, "user-456", fetchedPosts).Return(nil).Once()
 			},
 			expectedError: nil,
 			expectResult:  true,
@@ -827,10 +993,13 @@ func TestService_GetDayUserTimelineFilled(t *testing.T) {
 			mockTimelineRepo := mocks.NewTimelineRepository(t)
 			mockPostRepo := mocks.NewPostRepository(t)
 			mockTimelineFilledRepo := mocks.NewDayUserTimelineFilledRepository(t)
+			mockRelationshipRepo := mocks.NewRelationshipRepository(t)
 
 			tt.setupMocks(mockPostRepo, mockTimelineRepo, mockTimelineFilledRepo)
+			mockRelationshipRepo.On("GetBlockedAuthorIDs", ctx, tt.filter.UserID).Return([]string{}, nil).Maybe()
+			mockRelationshipRepo.On("GetMutedAuthorIDs", ctx, tt.filter.UserID).Return([]string{}, nil).Maybe()
 
-			service := NewTimelineService(mockTimelineRepo, mockPostRepo, mockTimelineFilledRepo)
+			service := NewTimelineService(mockTimelineRepo, mockPostRepo, mockPostRepo, mockTimelineFilledRepo, mockRelationshipRepo)
 
 			// Act
 			result, err := service.GetDayUserTimelineFilled(ctx, tt.filter)
@@ -846,6 +1015,16 @@ func TestService_GetDayUserTimelineFilled(t *testing.T) {
 					assert.NotNil(t, result)
 					assert.Equal(t, tt.filter.UserID, result.UserID)
 				}
+				if tt.expectedSource != "" {
+					assert.Equal(t, tt.expectedSource, result.Source)
+				}
+				if tt.expectedPostIDs != nil {
+					var postIDs []string
+					for _, post := range result.Posts {
+						postIDs = append(postIDs, post.ID)
+					}
+					assert.Equal(t, tt.expectedPostIDs, postIDs)
+				}
 			}
 
 			mockTimelineFilledRepo.AssertExpectations(t)
@@ -855,6 +1034,490 @@ func TestService_GetDayUserTimelineFilled(t *testing.T) {
 	}
 }
 
+func TestService_GetDayUserTimelineFilled_HiddenAuthors(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	filter := day_timeline_filled.DayUserTimelineFilledFilter{
+		UserID:    "user-456",
+		FromDay:   now.Day(),
+		FromMonth: int(now.Month()),
+		FromYear:  now.Year(),
+		ToDay:     now.Day(),
+		ToMonth:   int(now.Month()),
+		ToYear:    now.Year(),
+	}
+
+	dayTimeline := &day_timeline_filled.DayUserTimelineFilled{
+		UserID:     "user-456",
+		LastUpdate: now,
+		Posts: []posts.Post{
+			{ID: "post-1", AuthorID: "author-1", PublishedAt: now},
+			{ID: "post-2", AuthorID: "author-blocked", PublishedAt: now},
+			{ID: "post-3", AuthorID: "author-muted", PublishedAt: now},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		setupMocks      func(mockRelationshipRepo *mocks.RelationshipRepository)
+		expectedError   error
+		expectedPostIDs []string
+	}{
+		{
+			name: "should filter blocked and muted authors",
+			setupMocks: func(mockRelationshipRepo *mocks.RelationshipRepository) {
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", ctx, "user-456").Return([]string{"author-blocked"}, nil).Once()
+				mockRelationshipRepo.On("GetMutedAuthorIDs", ctx, "user-456").Return([]string{"author-muted"}, nil).Once()
+			},
+			expectedPostIDs: []string{"post-1"},
+		},
+		{
+			name: "should return every post when nobody is hidden",
+			setupMocks: func(mockRelationshipRepo *mocks.RelationshipRepository) {
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", ctx, "user-456").Return([]string{}, nil).Once()
+				mockRelationshipRepo.On("GetMutedAuthorIDs", ctx, "user-456").Return(nil, nil).Once()
+			},
+			expectedPostIDs: []string{"post-1", "post-2", "post-3"},
+		},
+		{
+			name: "should return error when relationship repository fails",
+			setupMocks: func(mockRelationshipRepo *mocks.RelationshipRepository) {
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", ctx, "user-456").Return(nil, errors.New("relationships error")).Once()
+			},
+			expectedError: errors.New("relationships error"),
+		},
+		{
+			name: "should fail the read when the followers service is down and nothing is cached",
+			setupMocks: func(mockRelationshipRepo *mocks.RelationshipRepository) {
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", ctx, "user-456").Return(nil, relationships.ErrUpstreamUnavailable).Once()
+			},
+			expectedError: relationships.ErrUpstreamUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineRepo := mocks.NewTimelineRepository(t)
+			mockPostRepo := mocks.NewPostRepository(t)
+			mockTimelineFilledRepo := mocks.NewDayUserTimelineFilledRepository(t)
+			mockRelationshipRepo := mocks.NewRelationshipRepository(t)
+
+			mockTimelineFilledRepo.On("GetDayUserTimelineFilled", ctx, filter).Return(dayTimeline, nil).Once()
+			tt.setupMocks(mockRelationshipRepo)
+
+			service := NewTimelineService(mockTimelineRepo, mockPostRepo, mockPostRepo, mockTimelineFilledRepo, mockRelationshipRepo)
+
+			// Act
+			result, err := service.GetDayUserTimelineFilled(ctx, filter)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			postIDs := make([]string, len(result.Posts))
+			for i, post := range result.Posts {
+				postIDs[i] = post.ID
+			}
+			assert.Equal(t, tt.expectedPostIDs, postIDs)
+			assert.Len(t, dayTimeline.Posts, 3)
+		})
+	}
+}
+
+func TestService_GetDayUserTimelineFilled_Timezone(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	buenosAires, err := time.LoadLocation("America/Argentina/Buenos_Aires")
+	assert.NoError(t, err)
+
+	filter := day_timeline_filled.DayUserTimelineFilledFilter{
+		UserID:    "user-456",
+		FromDay:   21,
+		FromMonth: 5,
+		FromYear:  2025,
+		ToDay:     21,
+		ToMonth:   5,
+		ToYear:    2025,
+		Location:  buenosAires,
+	}
+
+	// A local day in UTC-3 is stored across two UTC days
+	storageFilter := day_timeline_filled.DayUserTimelineFilledFilter{
+		UserID:    "user-456",
+		FromDay:   21,
+		FromMonth: 5,
+		FromYear:  2025,
+		ToDay:     22,
+		ToMonth:   5,
+		ToYear:    2025,
+	}
+
+	mockTimelineRepo := mocks.NewTimelineRepository(t)
+	mockPostRepo := mocks.NewPostRepository(t)
+	mockTimelineFilledRepo := mocks.NewDayUserTimelineFilledRepository(t)
+	mockRelationshipRepo := mocks.NewRelationshipRepository(t)
+
+	mockTimelineFilledRepo.On("GetDayUserTimelineFilled", ctx, storageFilter).Return(&day_timeline_filled.DayUserTimelineFilled{
+		UserID: "user-456",
+		Posts: []posts.Post{
+			{ID: "post-previous-local-day", PublishedAt: time.Date(2025, 5, 21, 2, 0, 0, 0, time.UTC)},
+			{ID: "post-morning", PublishedAt: time.Date(2025, 5, 21, 12, 0, 0, 0, time.UTC)},
+			{ID: "post-evening", PublishedAt: time.Date(2025, 5, 22, 2, 30, 0, 0, time.UTC)},
+			{ID: "post-next-local-day", PublishedAt: time.Date(2025, 5, 22, 3, 0, 0, 0, time.UTC)},
+		},
+	}, nil).Once()
+	mockRelationshipRepo.On("GetBlockedAuthorIDs", ctx, "user-456").Return([]string{}, nil).Once()
+	mockRelationshipRepo.On("GetMutedAuthorIDs", ctx, "user-456").Return([]string{}, nil).Once()
+
+	service := NewTimelineService(mockTimelineRepo, mockPostRepo, mockPostRepo, mockTimelineFilledRepo, mockRelationshipRepo)
+
+	// Act
+	result, err := service.GetDayUserTimelineFilled(ctx, filter)
+
+	// Assert
+	assert.NoError(t, err)
+	postIDs := make([]string, len(result.Posts))
+	for i, post := range result.Posts {
+		postIDs[i] = post.ID
+	}
+	assert.Equal(t, []string{"post-morning", "post-evening"}, postIDs)
+}
+
+func TestService_UpdatePost(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	now := time.Now().UTC()
+	oldTime := now.Add(-1 * time.Hour)
+
+	editedPost := &posts.Post{
+		ID:          "post-123",
+		Contents:    []posts.Content{{Type: "text", Text: stringPtr("edited content")}},
+		AuthorID:    "author-789",
+		PublishedAt: oldTime,
+		UpdatedAt:   now,
+	}
+
+	filter := day_timeline_filled.DayUserTimelineFilledFilter{
+		UserID:    "user-456",
+		FromDay:   oldTime.Day(),
+		FromMonth: int(oldTime.Month()),
+		FromYear:  oldTime.Year(),
+	}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository)
+		expectedError error
+		expectedPost  *posts.Post
+	}{
+		{
+			name: "should update post when user has an older version",
+			setupMocks: func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				mockTimelineRepo.On("GetUserPostTimeline", ctx, "user-456", "post-123").Return(&timeline.UserTimeline{UserID: "user-456"}, nil).Once()
+				mockPostRepo.On("GetPostById", ctx, "post-123").Return(editedPost, nil).Once()
+				mockTimelineFilledRepo.On("GetDayUserTimelineFilled", ctx, filter).Return(&day_timeline_filled.DayUserTimelineFilled{
+					UserID: "user-456",
+					Posts:  []posts.Post{{ID: "post-123", AuthorID: "author-789", PublishedAt: oldTime, UpdatedAt: oldTime}},
+				}, nil).Once()
+				mockTimelineFilledRepo.On("UpdatePosts", ctx, "user-456", editedPost).Return(nil).Once()
+			},
+			expectedError: nil,
+			expectedPost:  editedPost,
+		},
+		{
+			name: "should not insert post when user does not have it",
+			setupMocks: func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				mockTimelineRepo.On("GetUserPostTimeline", ctx, "user-456", "post-123").Return(nil, timeline.ErrUserTimelineNotFound).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name: "should discard edit when user has a newer version",
+			setupMocks: func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				mockTimelineRepo.On("GetUserPostTimeline", ctx, "user-456", "post-123").Return(&timeline.UserTimeline{UserID: "user-456"}, nil).Once()
+				mockPostRepo.On("GetPostById", ctx, "post-123").Return(editedPost, nil).Once()
+				mockTimelineFilledRepo.On("GetDayUserTimelineFilled", ctx, filter).Return(&day_timeline_filled.DayUserTimelineFilled{
+					UserID: "user-456",
+					Posts:  []posts.Post{{ID: "post-123", AuthorID: "author-789", PublishedAt: oldTime, UpdatedAt: now.Add(time.Minute)}},
+				}, nil).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name: "should return error when timeline repository fails",
+			setupMocks: func(mockPostRepo *mocks.PostRepository, mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				mockTimelineRepo.On("GetUserPostTimeline", ctx, "user-456", "post-123").Return(nil, timeline.ErrUserTimelineInternal).Once()
+			},
+			expectedError: timeline.ErrUserTimelineInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineRepo := mocks.NewTimelineRepository(t)
+			mockCachedPostRepo := mocks.NewPostRepository(t)
+			mockPostRepo := mocks.NewPostRepository(t)
+			mockTimelineFilledRepo := mocks.NewDayUserTimelineFilledRepository(t)
+			mockRelationshipRepo := mocks.NewRelationshipRepository(t)
+
+			tt.setupMocks(mockPostRepo, mockTimelineRepo, mockTimelineFilledRepo)
+
+			service := NewTimelineService(mockTimelineRepo, mockCachedPostRepo, mockPostRepo, mockTimelineFilledRepo, mockRelationshipRepo)
+
+			// Act
+			updatedPost, err := service.UpdatePost(ctx, "post-123", "user-456")
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPost, updatedPost)
+			}
+		})
+	}
+}
+
+func TestService_BackfillPosts(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	post := posts.Post{
+		ID:          "post-123",
+		Contents:    []posts.Content{{Type: "text", Text: stringPtr("test content")}},
+		AuthorID:    "author-789",
+		PublishedAt: now,
+		UpdatedAt:   now,
+	}
+
+	filter := day_timeline_filled.DayUserTimelineFilledFilter{
+		UserID:    "user-456",
+		FromDay:   now.Day(),
+		FromMonth: int(now.Month()),
+		FromYear:  now.Year(),
+	}
+
+	tests := []struct {
+		name          string
+		posts         []posts.Post
+		setupMocks    func(mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository)
+		expectedError error
+	}{
+		{
+			name:  "should add posts that are not in timeline",
+			posts: []posts.Post{post},
+			setupMocks: func(mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				mockTimelineRepo.On("GetUserPostTimeline", ctx, "user-456", "post-123").Return(nil, timeline.ErrUserTimelineNotFound).Once()
+				mockTimelineRepo.On("AddPostToUserTimeline", ctx, "user-456", timeline.CreateTimelinePostFromPost(post)).Return(nil).Once()
+				mockTimelineFilledRepo.On("GetDayUserTimelineFilled", ctx, filter).Return(&day_timeline_filled.DayUserTimelineFilled{UserID: "user-456"}, nil).Once()
+				mockTimelineFilledRepo.On("AddPosts", ctx, "user-456", []posts.Post{post}).Return(nil).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name:  "should skip posts already backfilled",
+			posts: []posts.Post{post},
+			setupMocks: func(mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				mockTimelineRepo.On("GetUserPostTimeline", ctx, "user-456", "post-123").Return(&timeline.UserTimeline{UserID: "user-456"}, nil).Once()
+				mockTimelineFilledRepo.On("GetDayUserTimelineFilled", ctx, filter).Return(&day_timeline_filled.DayUserTimelineFilled{
+					UserID: "user-456",
+					Posts:  []posts.Post{post},
+				}, nil).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name:  "should return error when AddPostToUserTimeline fails",
+			posts: []posts.Post{post},
+			setupMocks: func(mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				mockTimelineRepo.On("GetUserPostTimeline", ctx, "user-456", "post-123").Return(nil, timeline.ErrUserTimelineNotFound).Once()
+				mockTimelineRepo.On("AddPostToUserTimeline", ctx, "user-456", timeline.CreateTimelinePostFromPost(post)).Return(errors.New("insert error")).Once()
+			},
+			expectedError: errors.New("insert error"),
+		},
+		{
+			name:  "should do nothing when there are no posts",
+			posts: []posts.Post{},
+			setupMocks: func(mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineRepo := mocks.NewTimelineRepository(t)
+			mockPostRepo := mocks.NewPostRepository(t)
+			mockTimelineFilledRepo := mocks.NewDayUserTimelineFilledRepository(t)
+			mockRelationshipRepo := mocks.NewRelationshipRepository(t)
+
+			tt.setupMocks(mockTimelineRepo, mockTimelineFilledRepo)
+
+			service := NewTimelineService(mockTimelineRepo, mockPostRepo, mockPostRepo, mockTimelineFilledRepo, mockRelationshipRepo)
+
+			// Act
+			err := service.BackfillPosts(ctx, "user-456", tt.posts)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_RemoveAuthorPosts(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	now := time.Now().UTC()
+	yesterday := now.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name          string
+		setupMocks    func(mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository)
+		expectedError error
+	}{
+		{
+			name: "should remove author posts from timeline and day snapshots",
+			setupMocks: func(mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				mockTimelineRepo.On("GetAuthorPostsFromTimeline", ctx, "user-456", "author-789").Return([]timeline.PostTimeline{
+					{PostID: "post-123", AuthorID: "author-789", PublishedAt: now},
+					{PostID: "post-456", AuthorID: "author-789", PublishedAt: yesterday},
+				}, nil).Once()
+				mockTimelineFilledRepo.On("RemovePosts", ctx, "user-456", []posts.Post{
+					{ID: "post-123", AuthorID: "author-789", PublishedAt: now},
+					{ID: "post-456", AuthorID: "author-789", PublishedAt: yesterday},
+				}).Return(nil).Once()
+				mockTimelineRepo.On("RemovePostsFromTimeline", ctx, "user-456", []string{"post-123", "post-456"}).Return(nil).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name: "should keep timeline rows when day snapshots fail so the removal can be retried",
+			setupMocks: func(mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				mockTimelineRepo.On("GetAuthorPostsFromTimeline", ctx, "user-456", "author-789").Return([]timeline.PostTimeline{
+					{PostID: "post-123", AuthorID: "author-789", PublishedAt: now},
+				}, nil).Once()
+				mockTimelineFilledRepo.On("RemovePosts", ctx, "user-456", mock.Anything).Return(errors.New("dynamo error")).Once()
+			},
+			expectedError: errors.New("dynamo error"),
+		},
+		{
+			name: "should not touch day snapshots when author has no posts in timeline",
+			setupMocks: func(mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				mockTimelineRepo.On("GetAuthorPostsFromTimeline", ctx, "user-456", "author-789").Return([]timeline.PostTimeline{}, nil).Once()
+			},
+			expectedError: nil,
+		},
+		{
+			name: "should return error when timeline repository fails",
+			setupMocks: func(mockTimelineRepo *mocks.TimelineRepository, mockTimelineFilledRepo *mocks.DayUserTimelineFilledRepository) {
+				mockTimelineRepo.On("GetAuthorPostsFromTimeline", ctx, "user-456", "author-789").Return(nil, errors.New("timeline error")).Once()
+			},
+			expectedError: errors.New("timeline error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineRepo := mocks.NewTimelineRepository(t)
+			mockPostRepo := mocks.NewPostRepository(t)
+			mockTimelineFilledRepo := mocks.NewDayUserTimelineFilledRepository(t)
+			mockRelationshipRepo := mocks.NewRelationshipRepository(t)
+
+			tt.setupMocks(mockTimelineRepo, mockTimelineFilledRepo)
+
+			service := NewTimelineService(mockTimelineRepo, mockPostRepo, mockPostRepo, mockTimelineFilledRepo, mockRelationshipRepo)
+
+			// Act
+			err := service.RemoveAuthorPosts(ctx, "user-456", "author-789")
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_GetNewPosts(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	since := time.Now().UTC().Add(-time.Hour)
+	newPosts := []timeline.PostTimeline{{PostID: "post-123", AuthorID: "author-789", PublishedAt: since.Add(time.Minute)}}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(mockTimelineRepo *mocks.TimelineRepository, mockRelationshipRepo *mocks.RelationshipRepository)
+		expectedError error
+		expectedPosts []timeline.PostTimeline
+	}{
+		{
+			name: "should exclude blocked and muted authors",
+			setupMocks: func(mockTimelineRepo *mocks.TimelineRepository, mockRelationshipRepo *mocks.RelationshipRepository) {
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", ctx, "user-456").Return([]string{"author-blocked"}, nil).Once()
+				mockRelationshipRepo.On("GetMutedAuthorIDs", ctx, "user-456").Return([]string{"author-muted", "author-blocked"}, nil).Once()
+				mockTimelineRepo.On("GetNewPosts", ctx, "user-456", timeline.NewPostsFilter{
+					Since:             since,
+					ExcludedAuthorIDs: []string{"author-blocked", "author-muted"},
+					Limit:             100,
+				}).Return(newPosts, nil).Once()
+			},
+			expectedPosts: newPosts,
+		},
+		{
+			name: "should return error when relationship repository fails",
+			setupMocks: func(mockTimelineRepo *mocks.TimelineRepository, mockRelationshipRepo *mocks.RelationshipRepository) {
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", ctx, "user-456").Return(nil, errors.New("relationships error")).Once()
+			},
+			expectedError: errors.New("relationships error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockTimelineRepo := mocks.NewTimelineRepository(t)
+			mockPostRepo := mocks.NewPostRepository(t)
+			mockTimelineFilledRepo := mocks.NewDayUserTimelineFilledRepository(t)
+			mockRelationshipRepo := mocks.NewRelationshipRepository(t)
+
+			tt.setupMocks(mockTimelineRepo, mockRelationshipRepo)
+
+			service := NewTimelineService(mockTimelineRepo, mockPostRepo, mockPostRepo, mockTimelineFilledRepo, mockRelationshipRepo)
+
+			// Act
+			result, err := service.GetNewPosts(ctx, "user-456", timeline.NewPostsFilter{Since: since, Limit: 100})
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPosts, result)
+			}
+		})
+	}
+}
+
 // Helper function to create string pointers
 func stringPtr(s string) *string {
 	return &s
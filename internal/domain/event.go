@@ -1,14 +1,28 @@
 package domain
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+	"uala-timeline-service/internal/domain/posts"
+)
 
 const (
-	UserTimelineAddPostTopic = "user_timeline.add_post"
+	UserTimelineAddPostTopic    = "user_timeline.add_post"
+	UserTimelineUpdatePostTopic = "user_timeline.update_post"
+	UserTimelineRemovePostTopic = "user_timeline.remove_post"
+
+	// Timeline stream events are published once the change is stored on the user timeline
+	TimelineStreamPostAdded   = "post_added"
+	TimelineStreamPostUpdated = "post_updated"
+	TimelineStreamPostRemoved = "post_removed"
 )
 
 type UserTimelineAddPostEvent struct {
 	PostID string `json:"post_id"`
 	UserID string `json:"user_id"`
+	// Post is only set when the created post event carried it
+	Post *posts.Post `json:"post,omitempty"`
 }
 
 func (p UserTimelineAddPostEvent) Key() string {
@@ -24,6 +38,174 @@ func (p UserTimelineAddPostEvent) Payload() []byte {
 	return payload
 }
 
-func NewUserTimelineAddPostEvent(userID string, postID string) UserTimelineAddPostEvent {
-	return UserTimelineAddPostEvent{PostID: postID, UserID: userID}
+// MarshalJSON sends the carried post with snake_case keys, the domain post has no tags
+// because it is stored as it is on the day snapshots.
+func (p UserTimelineAddPostEvent) MarshalJSON() ([]byte, error) {
+	var post *eventPost
+	if p.Post != nil {
+		post = newEventPost(*p.Post)
+	}
+	return json.Marshal(struct {
+		PostID string     `json:"post_id"`
+		UserID string     `json:"user_id"`
+		Post   *eventPost `json:"post,omitempty"`
+	}{PostID: p.PostID, UserID: p.UserID, Post: post})
+}
+
+func NewUserTimelineAddPostEvent(userID string, postID string, post *posts.Post) UserTimelineAddPostEvent {
+	return UserTimelineAddPostEvent{PostID: postID, UserID: userID, Post: post}
+}
+
+type eventPost struct {
+	ID          string         `json:"id"`
+	Contents    []eventContent `json:"contents"`
+	AuthorID    string         `json:"author_id"`
+	PublishedAt time.Time      `json:"published_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+type eventContent struct {
+	Type            string            `json:"type"`
+	Text            *string           `json:"text"`
+	Url             *string           `json:"url,omitempty"`
+	Media           *eventMedia       `json:"media,omitempty"`
+	Preview         *eventLinkPreview `json:"preview,omitempty"`
+	MentionedUserID *string           `json:"mentioned_user_id,omitempty"`
+	Data            json.RawMessage   `json:"data,omitempty"`
+}
+
+type eventMedia struct {
+	MimeType     string  `json:"mime_type"`
+	Width        int     `json:"width"`
+	Height       int     `json:"height"`
+	DurationMs   int     `json:"duration_ms,omitempty"`
+	ThumbnailUrl *string `json:"thumbnail_url,omitempty"`
+	AltText      *string `json:"alt_text,omitempty"`
+}
+
+type eventLinkPreview struct {
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+	ImageUrl    *string `json:"image_url,omitempty"`
+	SiteName    *string `json:"site_name,omitempty"`
+}
+
+func newEventPost(post posts.Post) *eventPost {
+	contents := make([]eventContent, len(post.Contents))
+	for i, content := range post.Contents {
+		contents[i] = eventContent{
+			Type:            content.Type,
+			Text:            content.Text,
+			Url:             content.Url,
+			MentionedUserID: content.MentionedUserID,
+			Data:            content.Data,
+		}
+		if content.Media != nil {
+			contents[i].Media = &eventMedia{
+				MimeType:     content.Media.MimeType,
+				Width:        content.Media.Width,
+				Height:       content.Media.Height,
+				DurationMs:   content.Media.DurationMs,
+				ThumbnailUrl: content.Media.ThumbnailUrl,
+				AltText:      content.Media.AltText,
+			}
+		}
+		if content.Preview != nil {
+			contents[i].Preview = &eventLinkPreview{
+				Title:       content.Preview.Title,
+				Description: content.Preview.Description,
+				ImageUrl:    content.Preview.ImageUrl,
+				SiteName:    content.Preview.SiteName,
+			}
+		}
+	}
+	return &eventPost{
+		ID:          post.ID,
+		Contents:    contents,
+		AuthorID:    post.AuthorID,
+		PublishedAt: post.PublishedAt,
+		UpdatedAt:   post.UpdatedAt,
+	}
+}
+
+type UserTimelineUpdatePostEvent struct {
+	PostID string `json:"post_id"`
+	UserID string `json:"user_id"`
+}
+
+func (p UserTimelineUpdatePostEvent) Key() string {
+	return p.PostID
+}
+
+func (p UserTimelineUpdatePostEvent) Topic() string {
+	return UserTimelineUpdatePostTopic
+}
+
+func (p UserTimelineUpdatePostEvent) Payload() []byte {
+	payload, _ := json.Marshal(p)
+	return payload
+}
+
+func NewUserTimelineUpdatePostEvent(userID string, postID string) UserTimelineUpdatePostEvent {
+	return UserTimelineUpdatePostEvent{PostID: postID, UserID: userID}
+}
+
+type UserTimelineRemovePostEvent struct {
+	PostID string `json:"post_id"`
+	UserID string `json:"user_id"`
+}
+
+func (p UserTimelineRemovePostEvent) Key() string {
+	return p.PostID
+}
+
+func (p UserTimelineRemovePostEvent) Topic() string {
+	return UserTimelineRemovePostTopic
+}
+
+func (p UserTimelineRemovePostEvent) Payload() []byte {
+	payload, _ := json.Marshal(p)
+	return payload
+}
+
+func NewUserTimelineRemovePostEvent(userID string, postID string) UserTimelineRemovePostEvent {
+	return UserTimelineRemovePostEvent{PostID: postID, UserID: userID}
+}
+
+// TimelineStreamEvent notifies the readers connected to a user timeline, it is published
+// on a subject per user so any replica can serve the stream.
+type TimelineStreamEvent struct {
+	Type   string     `json:"type"`
+	UserID string     `json:"user_id"`
+	Post   posts.Post `json:"post"`
+}
+
+func (p TimelineStreamEvent) Key() string {
+	return p.Post.ID
+}
+
+func (p TimelineStreamEvent) Topic() string {
+	return fmt.Sprintf("%s.%s", TimelineStreamTopic(p.UserID), p.Type)
+}
+
+func (p TimelineStreamEvent) Payload() []byte {
+	payload, _ := json.Marshal(p)
+	return payload
+}
+
+// TimelineStreamTopic is the subject prefix of every stream event of the user.
+func TimelineStreamTopic(userID string) string {
+	return fmt.Sprintf("timeline_stream.%s", userID)
+}
+
+func NewTimelineStreamPostAddedEvent(userID string, post posts.Post) TimelineStreamEvent {
+	return TimelineStreamEvent{Type: TimelineStreamPostAdded, UserID: userID, Post: post}
+}
+
+func NewTimelineStreamPostUpdatedEvent(userID string, post posts.Post) TimelineStreamEvent {
+	return TimelineStreamEvent{Type: TimelineStreamPostUpdated, UserID: userID, Post: post}
+}
+
+func NewTimelineStreamPostRemovedEvent(userID string, post posts.Post) TimelineStreamEvent {
+	return TimelineStreamEvent{Type: TimelineStreamPostRemoved, UserID: userID, Post: post}
 }
@@ -1,8 +1,15 @@
 package follows
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
-//go:generate mockery --name=FollowRepository --filename=mocks_follow_repository.go
+var (
+	ErrUpstreamUnavailable = errors.New("follows.upstream_unavailable")
+)
+
+//go:generate mockery --name=FollowRepository --filename=mocks_follow_repository.go --output=../../../mocks --outpkg=mocks
 type FollowRepository interface {
 	GetUserFollowerIDs(ctx context.Context, userID string) ([]string, error)
 }
@@ -0,0 +1,63 @@
+package post_stats
+
+import (
+	"context"
+	"time"
+)
+
+// Engagement kinds counted on each post.
+const (
+	EngagementLike   = "like"
+	EngagementRepost = "repost"
+	EngagementReply  = "reply"
+)
+
+//go:generate mockery --name=PostStatsRepository --filename=mocks_post_stats_repository.go --output=../../../mocks --outpkg=mocks
+type PostStatsRepository interface {
+	// AddEngagement counts the engagement on its post once, repeated ones and the ones taken
+	// back at or after At are ignored
+	AddEngagement(ctx context.Context, engagement Engagement) error
+	// RemoveEngagement stops counting the engagement added at or before At. It is recorded
+	// even when the engagement is unknown, so an add delivered after its undo is ignored
+	RemoveEngagement(ctx context.Context, engagement Engagement) error
+	// MGetPostStats returns the stats keyed by post ID, posts never engaged with are left out
+	MGetPostStats(ctx context.Context, postIDs []string) (map[string]PostStats, error)
+}
+
+//go:generate mockery --name=AuthorEngagementRepository --filename=mocks_author_engagement_repository.go --output=../../../mocks --outpkg=mocks
+type AuthorEngagementRepository interface {
+	// CountEngagementsByAuthor returns how many posts of each author in the user timeline the
+	// user likes, reposts or replies, keyed by author ID. Authors never engaged with are left out
+	CountEngagementsByAuthor(ctx context.Context, userID string, authorIDs []string) (map[string]int, error)
+}
+
+// Engagement is a like, repost or reply of a user on a post. ID tells repeated engagements
+// apart: the user for likes and reposts, since a user likes a post once, and the reply for
+// replies. At is when the engagement was added or taken back, it orders the events.
+type Engagement struct {
+	PostID string
+	Kind   string
+	ID     string
+	UserID string
+	At     time.Time
+}
+
+type PostStats struct {
+	PostID  string
+	Likes   int
+	Reposts int
+	Replies int
+}
+
+// Add returns the stats with the engagement kind counted delta more times.
+func (s PostStats) Add(kind string, delta int) PostStats {
+	switch kind {
+	case EngagementLike:
+		s.Likes += delta
+	case EngagementRepost:
+		s.Reposts += delta
+	case EngagementReply:
+		s.Replies += delta
+	}
+	return s
+}
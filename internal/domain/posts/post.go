@@ -2,13 +2,35 @@ package posts
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"slices"
 	"time"
 )
 
+var (
+	ErrUpstreamUnavailable = errors.New("posts.upstream_unavailable")
+	ErrPostNotFound        = errors.New("posts.post_not_found")
+)
+
 //go:generate mockery --name=PostRepository --filename=mocks_post_repository.go --output=../../../mocks --outpkg=mocks
 type PostRepository interface {
-	MGetPosts(ctx context.Context, postIDs []string) ([]Post, error)
+	MGetPosts(ctx context.Context, postIDs []string) (*MGetPostsResult, error)
 	GetPostById(ctx context.Context, id string) (*Post, error)
+	GetAuthorPosts(ctx context.Context, authorID string, since time.Time) ([]Post, error)
+}
+
+// PostCache drops the cached copy of a post once the posts service changes or deletes it.
+type PostCache interface {
+	Invalidate(ctx context.Context, postID string) error
+}
+
+// MGetPostsResult keeps the found posts in the order of the requested IDs. NotFoundIDs
+// were deleted or never existed, UnavailableIDs could not be fetched and may exist.
+type MGetPostsResult struct {
+	Posts          []Post
+	NotFoundIDs    []string
+	UnavailableIDs []string
 }
 
 type Post struct {
@@ -19,8 +41,63 @@ type Post struct {
 	UpdatedAt   time.Time
 }
 
+// Edited reports whether the post changed after it was published.
+func (p Post) Edited() bool {
+	return p.UpdatedAt.After(p.PublishedAt)
+}
+
+// Content types known by the service, contents of other types are kept as they come.
+const (
+	ContentTypeText    = "text"
+	ContentTypeImage   = "image"
+	ContentTypeVideo   = "video"
+	ContentTypeLink    = "link"
+	ContentTypeMention = "mention"
+	ContentTypeHashtag = "hashtag"
+)
+
+var knownContentTypes = []string{
+	ContentTypeText,
+	ContentTypeImage,
+	ContentTypeVideo,
+	ContentTypeLink,
+	ContentTypeMention,
+	ContentTypeHashtag,
+}
+
+// Content is a block of a post. Text is the text of text contents, the handle of mentions
+// and the tag of hashtags, Url the address of media and links. Posts are stored as JSON on
+// the day snapshots, so the fields that only some types have are omitted when empty.
 type Content struct {
 	Type string
 	Text *string
 	Url  *string
+	// Media is set on image and video contents
+	Media *Media `json:",omitempty"`
+	// Preview is set on link contents
+	Preview *LinkPreview `json:",omitempty"`
+	// MentionedUserID is set on mention contents
+	MentionedUserID *string `json:",omitempty"`
+	// Data is the content as the posts service sent it, only set on unknown types
+	Data json.RawMessage `json:",omitempty"`
+}
+
+func IsKnownContentType(contentType string) bool {
+	return slices.Contains(knownContentTypes, contentType)
+}
+
+type Media struct {
+	MimeType     string
+	Width        int
+	Height       int
+	DurationMs   int `json:",omitempty"`
+	ThumbnailUrl *string
+	AltText      *string
+}
+
+type LinkPreview struct {
+	Title       *string
+	Description *string
+	ImageUrl    *string
+	SiteName    *string
 }
@@ -0,0 +1,67 @@
+package ranking
+
+import (
+	"context"
+	"uala-timeline-service/internal/domain/post_stats"
+	"uala-timeline-service/internal/domain/posts"
+)
+
+var _ SignalRepository = (*AffinitySignalRepository)(nil)
+
+// AffinitySignalRepository adds the reader affinity with each author to the signals of the
+// wrapped repository. The affinity is the engagements of the reader with the author posts
+// over the ones with the most engaged author of the read, so it goes from 0 to 1.
+type AffinitySignalRepository struct {
+	signalRepository           SignalRepository
+	authorEngagementRepository post_stats.AuthorEngagementRepository
+}
+
+func NewAffinitySignalRepository(signalRepository SignalRepository, authorEngagementRepository post_stats.AuthorEngagementRepository) *AffinitySignalRepository {
+	return &AffinitySignalRepository{
+		signalRepository:           signalRepository,
+		authorEngagementRepository: authorEngagementRepository,
+	}
+}
+
+func (r *AffinitySignalRepository) GetSignals(ctx context.Context, userID string, timelinePosts []posts.Post) (map[string]Signals, error) {
+	signals, err := r.signalRepository.GetSignals(ctx, userID, timelinePosts)
+	if err != nil {
+		return nil, err
+	}
+
+	authorIDs := make([]string, 0, len(timelinePosts))
+	seen := make(map[string]struct{}, len(timelinePosts))
+	for _, post := range timelinePosts {
+		if _, ok := seen[post.AuthorID]; ok {
+			continue
+		}
+		seen[post.AuthorID] = struct{}{}
+		authorIDs = append(authorIDs, post.AuthorID)
+	}
+	engagements, err := r.authorEngagementRepository.CountEngagementsByAuthor(ctx, userID, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	most := 0
+	for _, count := range engagements {
+		most = max(most, count)
+	}
+	if most == 0 {
+		return signals, nil
+	}
+	if signals == nil {
+		signals = make(map[string]Signals, len(timelinePosts))
+	}
+
+	for _, post := range timelinePosts {
+		count := engagements[post.AuthorID]
+		if count == 0 {
+			continue
+		}
+		postSignals := signals[post.ID]
+		postSignals.AuthorAffinity = float64(count) / float64(most)
+		signals[post.ID] = postSignals
+	}
+	return signals, nil
+}
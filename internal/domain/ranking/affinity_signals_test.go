@@ -0,0 +1,81 @@
+package ranking
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"uala-timeline-service/internal/domain/posts"
+)
+
+// authorEngagementRepositoryFunc serves the engagements of the tests, the mocks package
+// imports this one.
+type authorEngagementRepositoryFunc func(ctx context.Context, userID string, authorIDs []string) (map[string]int, error)
+
+func (f authorEngagementRepositoryFunc) CountEngagementsByAuthor(ctx context.Context, userID string, authorIDs []string) (map[string]int, error) {
+	return f(ctx, userID, authorIDs)
+}
+
+func TestAffinitySignalRepository_GetSignals(t *testing.T) {
+	ctx := context.Background()
+	timelinePosts := []posts.Post{
+		{ID: "post-1", AuthorID: "author-1"},
+		{ID: "post-2", AuthorID: "author-2"},
+		{ID: "post-3", AuthorID: "author-1"},
+		{ID: "post-4", AuthorID: "author-3"},
+	}
+
+	tests := []struct {
+		name            string
+		signals         map[string]Signals
+		engagements     map[string]int
+		engagementsErr  error
+		expectedSignals map[string]Signals
+		expectedError   error
+	}{
+		{
+			name:        "should scale the affinity by the most engaged author",
+			signals:     map[string]Signals{"post-2": {Likes: 3}},
+			engagements: map[string]int{"author-1": 4, "author-2": 1},
+			expectedSignals: map[string]Signals{
+				"post-1": {AuthorAffinity: 1},
+				"post-2": {AuthorAffinity: 0.25, Likes: 3},
+				"post-3": {AuthorAffinity: 1},
+			},
+		},
+		{
+			name:            "should keep the signals when the reader never engaged",
+			signals:         map[string]Signals{"post-2": {Likes: 3}},
+			engagements:     map[string]int{},
+			expectedSignals: map[string]Signals{"post-2": {Likes: 3}},
+		},
+		{
+			name:           "should fail when the engagements cannot be read",
+			engagementsErr: errors.New("boom"),
+			expectedError:  errors.New("boom"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			repository := NewAffinitySignalRepository(
+				signalRepositoryFunc(func(ctx context.Context, userID string, timelinePosts []posts.Post) (map[string]Signals, error) {
+					return tt.signals, nil
+				}),
+				authorEngagementRepositoryFunc(func(ctx context.Context, userID string, authorIDs []string) (map[string]int, error) {
+					assert.Equal(t, "user-456", userID)
+					assert.Equal(t, []string{"author-1", "author-2", "author-3"}, authorIDs)
+					return tt.engagements, tt.engagementsErr
+				}),
+			)
+
+			// Act
+			signals, err := repository.GetSignals(ctx, "user-456", timelinePosts)
+
+			// Assert
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedSignals, signals)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package ranking
+
+import (
+	"context"
+	"uala-timeline-service/internal/domain/post_stats"
+	"uala-timeline-service/internal/domain/posts"
+)
+
+var _ SignalRepository = (*PostStatsSignalRepository)(nil)
+
+// PostStatsSignalRepository reads the engagement signals from the post stats, the author
+// affinity is added by AffinitySignalRepository.
+type PostStatsSignalRepository struct {
+	postStatsRepository post_stats.PostStatsRepository
+}
+
+func NewPostStatsSignalRepository(postStatsRepository post_stats.PostStatsRepository) *PostStatsSignalRepository {
+	return &PostStatsSignalRepository{
+		postStatsRepository: postStatsRepository,
+	}
+}
+
+func (r *PostStatsSignalRepository) GetSignals(ctx context.Context, _ string, timelinePosts []posts.Post) (map[string]Signals, error) {
+	postIDs := make([]string, len(timelinePosts))
+	for i, post := range timelinePosts {
+		postIDs[i] = post.ID
+	}
+	stats, err := r.postStatsRepository.MGetPostStats(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	signals := make(map[string]Signals, len(stats))
+	for postID, postStats := range stats {
+		signals[postID] = Signals{
+			Likes:   postStats.Likes,
+			Reposts: postStats.Reposts,
+			Replies: postStats.Replies,
+		}
+	}
+	return signals, nil
+}
@@ -0,0 +1,102 @@
+package ranking
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"uala-timeline-service/internal/domain/posts"
+)
+
+// Sorts of a timeline read.
+const (
+	// SortLatest shows the newest posts first
+	SortLatest = "latest"
+	// SortTop shows the posts with the highest score first
+	SortTop = "top"
+)
+
+var Sorts = []string{SortLatest, SortTop}
+
+func IsKnownSort(sort string) bool {
+	return slices.Contains(Sorts, sort)
+}
+
+type Ranker interface {
+	// Rank returns the posts read by the user in the order they are shown
+	Rank(ctx context.Context, userID string, timelinePosts []posts.Post) ([]posts.Post, error)
+}
+
+//go:generate mockery --name=SignalRepository --filename=mocks_signal_repository.go --output=../../../mocks --outpkg=mocks
+type SignalRepository interface {
+	// GetSignals returns the signals of the posts read by the user keyed by post ID, posts
+	// without signals are left out
+	GetSignals(ctx context.Context, userID string, timelinePosts []posts.Post) (map[string]Signals, error)
+}
+
+type Signals struct {
+	// AuthorAffinity is how close the reader is to the post author, between 0 and 1
+	AuthorAffinity float64
+	Likes          int
+	Reposts        int
+	Replies        int
+}
+
+var _ Ranker = Chronological{}
+
+// Chronological ranks the newest posts first, the order of every timeline before ranking.
+type Chronological struct{}
+
+func (Chronological) Rank(_ context.Context, _ string, timelinePosts []posts.Post) ([]posts.Post, error) {
+	SortNewestFirst(timelinePosts)
+	return timelinePosts, nil
+}
+
+// SortNewestFirst orders posts by publish date and breaks ties by ID so pages are stable.
+func SortNewestFirst(timelinePosts []posts.Post) {
+	sort.SliceStable(timelinePosts, func(i, j int) bool {
+		return newerThan(timelinePosts[i], timelinePosts[j])
+	})
+}
+
+func newerThan(post posts.Post, other posts.Post) bool {
+	if post.PublishedAt.Equal(other.PublishedAt) {
+		return post.ID > other.ID
+	}
+	return post.PublishedAt.After(other.PublishedAt)
+}
+
+// Selector picks the ranker of each read: the requested sort, else the sort set for the
+// user, else the default one.
+type Selector struct {
+	rankers     map[string]Ranker
+	defaultSort string
+	userSorts   map[string]string
+}
+
+// NewSelector takes the rankers keyed by sort and the sorts of the users that do not read
+// with the default one, keyed by user ID. Sorts without ranker are read chronologically.
+func NewSelector(rankers map[string]Ranker, defaultSort string, userSorts map[string]string) *Selector {
+	if defaultSort == "" {
+		defaultSort = SortLatest
+	}
+	return &Selector{
+		rankers:     rankers,
+		defaultSort: defaultSort,
+		userSorts:   userSorts,
+	}
+}
+
+// Select returns the sort of the read and its ranker, the requested sort may be empty.
+func (s *Selector) Select(userID string, requestedSort string) (string, Ranker) {
+	timelineSort := requestedSort
+	if timelineSort == "" {
+		timelineSort = s.defaultSort
+		if userSort, ok := s.userSorts[userID]; ok {
+			timelineSort = userSort
+		}
+	}
+	if ranker, ok := s.rankers[timelineSort]; ok {
+		return timelineSort, ranker
+	}
+	return timelineSort, Chronological{}
+}
@@ -0,0 +1,84 @@
+package ranking
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+	"uala-timeline-service/internal/domain/posts"
+)
+
+var _ Ranker = (*Scored)(nil)
+
+// Weights of the score of each post:
+//
+//	Recency * 0.5^(age / RecencyHalfLife) + AuthorAffinity * affinity
+//	  + Likes * ln(1 + likes) + Reposts * ln(1 + reposts) + Replies * ln(1 + replies)
+//
+// Counters are damped so a viral post does not bury every recent one.
+type Weights struct {
+	Recency         float64
+	RecencyHalfLife time.Duration
+	AuthorAffinity  float64
+	Likes           float64
+	Reposts         float64
+	Replies         float64
+}
+
+// Scored ranks the posts with the highest score first, ties are broken newest first.
+type Scored struct {
+	signalRepository SignalRepository
+	weights          Weights
+	now              func() time.Time
+}
+
+// NewScored takes an optional signal repository, posts are scored by recency alone
+// without one.
+func NewScored(signalRepository SignalRepository, weights Weights) *Scored {
+	return &Scored{
+		signalRepository: signalRepository,
+		weights:          weights,
+		now:              time.Now,
+	}
+}
+
+func (s *Scored) Rank(ctx context.Context, userID string, timelinePosts []posts.Post) ([]posts.Post, error) {
+	signals := map[string]Signals{}
+	if s.signalRepository != nil && len(timelinePosts) > 0 {
+		var err error
+		signals, err = s.signalRepository.GetSignals(ctx, userID, timelinePosts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := s.now()
+	scores := make(map[string]float64, len(timelinePosts))
+	for _, post := range timelinePosts {
+		scores[post.ID] = s.Score(post, signals[post.ID], now)
+	}
+
+	sort.SliceStable(timelinePosts, func(i, j int) bool {
+		left, right := scores[timelinePosts[i].ID], scores[timelinePosts[j].ID]
+		if left == right {
+			return newerThan(timelinePosts[i], timelinePosts[j])
+		}
+		return left > right
+	})
+	return timelinePosts, nil
+}
+
+// Score returns the score of the post at now, posts published after now are as recent as
+// a post published at now.
+func (s *Scored) Score(post posts.Post, signals Signals, now time.Time) float64 {
+	recency := 1.0
+	if age := now.Sub(post.PublishedAt); age > 0 && s.weights.RecencyHalfLife > 0 {
+		recency = math.Pow(0.5, float64(age)/float64(s.weights.RecencyHalfLife))
+	}
+
+	return s.weights.Recency*recency +
+		s.weights.AuthorAffinity*signals.AuthorAffinity +
+		s.weights.Likes*math.Log1p(float64(signals.Likes)) +
+		s.weights.Reposts*math.Log1p(float64(signals.Reposts)) +
+		s.weights.Replies*math.Log1p(float64(signals.Replies))
+}
@@ -0,0 +1,159 @@
+package ranking
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+	"uala-timeline-service/internal/domain/posts"
+)
+
+// signalRepositoryFunc serves the signals of the tests, the mocks package imports this one.
+type signalRepositoryFunc func(ctx context.Context, userID string, timelinePosts []posts.Post) (map[string]Signals, error)
+
+func (f signalRepositoryFunc) GetSignals(ctx context.Context, userID string, timelinePosts []posts.Post) (map[string]Signals, error) {
+	return f(ctx, userID, timelinePosts)
+}
+
+func TestScored_Score(t *testing.T) {
+	now := time.Date(2025, 5, 21, 12, 0, 0, 0, time.UTC)
+	weights := Weights{Recency: 2, RecencyHalfLife: 6 * time.Hour, AuthorAffinity: 1.5, Likes: 0.2, Reposts: 0.3, Replies: 0.25}
+
+	tests := []struct {
+		name          string
+		publishedAt   time.Time
+		signals       Signals
+		expectedScore float64
+	}{
+		{
+			name:          "should score a post published now with its full recency weight",
+			publishedAt:   now,
+			expectedScore: 2,
+		},
+		{
+			name:          "should halve the recency every half life",
+			publishedAt:   now.Add(-12 * time.Hour),
+			expectedScore: 0.5,
+		},
+		{
+			name:          "should not score posts from the future above the recency weight",
+			publishedAt:   now.Add(time.Hour),
+			expectedScore: 2,
+		},
+		{
+			name:          "should add the author affinity and the damped counters",
+			publishedAt:   now.Add(-6 * time.Hour),
+			signals:       Signals{AuthorAffinity: 0.5, Likes: 99, Reposts: 9, Replies: 0},
+			expectedScore: 1 + 0.75 + 0.2*math.Log(100) + 0.3*math.Log(10),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			ranker := NewScored(nil, weights)
+
+			// Act
+			score := ranker.Score(posts.Post{ID: "post-123", PublishedAt: tt.publishedAt}, tt.signals, now)
+
+			// Assert
+			assert.InDelta(t, tt.expectedScore, score, 1e-9)
+		})
+	}
+}
+
+func TestScored_Rank(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 5, 21, 12, 0, 0, 0, time.UTC)
+	weights := Weights{Recency: 1, RecencyHalfLife: time.Hour, AuthorAffinity: 1, Likes: 0.5}
+	timelinePosts := []posts.Post{
+		{ID: "post-new", AuthorID: "author-1", PublishedAt: now},
+		{ID: "post-tie-a", AuthorID: "author-1", PublishedAt: now.Add(-3 * time.Hour)},
+		{ID: "post-tie-b", AuthorID: "author-1", PublishedAt: now.Add(-3 * time.Hour)},
+		{ID: "post-friend", AuthorID: "author-2", PublishedAt: now.Add(-2 * time.Hour)},
+		{ID: "post-viral", AuthorID: "author-3", PublishedAt: now.Add(-5 * time.Hour)},
+	}
+
+	tests := []struct {
+		name            string
+		signals         map[string]Signals
+		signalsErr      error
+		expectedPostIDs []string
+		expectedError   error
+	}{
+		{
+			name: "should rank by score, close authors above new posts, and break ties newest first",
+			signals: map[string]Signals{
+				"post-friend": {AuthorAffinity: 0.9},
+				"post-viral":  {Likes: 999},
+			},
+			expectedPostIDs: []string{"post-viral", "post-friend", "post-new", "post-tie-b", "post-tie-a"},
+		},
+		{
+			name:          "should fail when the signals cannot be read",
+			signalsErr:    errors.New("boom"),
+			expectedError: errors.New("boom"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			var requestedPostIDs []string
+			ranker := NewScored(signalRepositoryFunc(func(ctx context.Context, userID string, timelinePosts []posts.Post) (map[string]Signals, error) {
+				assert.Equal(t, "user-456", userID)
+				requestedPostIDs = append(requestedPostIDs, postIDs(timelinePosts)...)
+				return tt.signals, tt.signalsErr
+			}), weights)
+			ranker.now = func() time.Time { return now }
+
+			// Act
+			rankedPosts, err := ranker.Rank(ctx, "user-456", append([]posts.Post{}, timelinePosts...))
+
+			// Assert
+			assert.Equal(t, tt.expectedError, err)
+			assert.Len(t, requestedPostIDs, len(timelinePosts))
+			if tt.expectedError == nil {
+				assert.Equal(t, tt.expectedPostIDs, postIDs(rankedPosts))
+			}
+		})
+	}
+}
+
+func TestSelector_Select(t *testing.T) {
+	scored := NewScored(nil, Weights{Recency: 1})
+	selector := NewSelector(map[string]Ranker{SortLatest: Chronological{}, SortTop: scored}, SortLatest, map[string]string{"user-top": SortTop})
+
+	tests := []struct {
+		name          string
+		userID        string
+		requestedSort string
+		expectedSort  string
+		expectedRank  Ranker
+	}{
+		{name: "should use the default sort", userID: "user-456", expectedSort: SortLatest, expectedRank: Chronological{}},
+		{name: "should use the sort set for the user", userID: "user-top", expectedSort: SortTop, expectedRank: scored},
+		{name: "should prefer the requested sort", userID: "user-top", requestedSort: SortLatest, expectedSort: SortLatest, expectedRank: Chronological{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			timelineSort, ranker := selector.Select(tt.userID, tt.requestedSort)
+
+			// Assert
+			assert.Equal(t, tt.expectedSort, timelineSort)
+			assert.Equal(t, tt.expectedRank, ranker)
+		})
+	}
+}
+
+func postIDs(timelinePosts []posts.Post) []string {
+	ids := make([]string, len(timelinePosts))
+	for i, post := range timelinePosts {
+		ids[i] = post.ID
+	}
+	return ids
+}
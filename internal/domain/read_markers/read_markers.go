@@ -0,0 +1,47 @@
+package read_markers
+
+import (
+	"context"
+	"errors"
+	"time"
+	"uala-timeline-service/internal/domain/posts"
+)
+
+var (
+	ErrReadMarkerNotFound = errors.New("read_marker.not_found")
+)
+
+//go:generate mockery --name=ReadMarkerRepository --filename=mocks_read_marker_repository.go --output=../../../mocks --outpkg=mocks
+type ReadMarkerRepository interface {
+	GetReadMarker(ctx context.Context, userID string, deviceID string) (*ReadMarker, error)
+	// GetLatestReadMarker returns the marker of the device that read further
+	GetLatestReadMarker(ctx context.Context, userID string) (*ReadMarker, error)
+	// SaveReadMarker only moves the marker forward and returns the marker kept
+	SaveReadMarker(ctx context.Context, marker ReadMarker) (*ReadMarker, error)
+}
+
+// ReadMarker is the last post seen by the user on a device, posts are ordered by publish
+// date and then by ID like the timeline.
+type ReadMarker struct {
+	UserID      string
+	DeviceID    string
+	PostID      string
+	PublishedAt time.Time
+	UpdatedAt   time.Time
+}
+
+// HasSeen reports whether the post is at or before the marker.
+func (m ReadMarker) HasSeen(post posts.Post) bool {
+	if post.PublishedAt.Equal(m.PublishedAt) {
+		return post.ID <= m.PostID
+	}
+	return post.PublishedAt.Before(m.PublishedAt)
+}
+
+// IsAfter reports whether the marker points to a newer post than the other one.
+func (m ReadMarker) IsAfter(other ReadMarker) bool {
+	if m.PublishedAt.Equal(other.PublishedAt) {
+		return m.PostID > other.PostID
+	}
+	return m.PublishedAt.After(other.PublishedAt)
+}
@@ -0,0 +1,16 @@
+package relationships
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrUpstreamUnavailable = errors.New("relationships.upstream_unavailable")
+)
+
+//go:generate mockery --name=RelationshipRepository --filename=mocks_relationship_repository.go --output=../../../mocks --outpkg=mocks
+type RelationshipRepository interface {
+	GetBlockedAuthorIDs(ctx context.Context, userID string) ([]string, error)
+	GetMutedAuthorIDs(ctx context.Context, userID string) ([]string, error)
+}
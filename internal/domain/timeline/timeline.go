@@ -18,6 +18,12 @@ type TimelineRepository interface {
 	AddPostToUserTimeline(ctx context.Context, userID string, timelinePost PostTimeline) error
 	RemovePostFromTimeline(ctx context.Context, userID string, timelinePost PostTimeline) error
 	GetUserPostTimeline(ctx context.Context, userID string, postId string) (*UserTimeline, error)
+	GetAuthorPostsFromTimeline(ctx context.Context, userID string, authorID string) ([]PostTimeline, error)
+	RemovePostsFromTimeline(ctx context.Context, userID string, postIDs []string) error
+	// GetPostIDsWithoutAuthor pages the posts stored before author_id was added, by post ID
+	GetPostIDsWithoutAuthor(ctx context.Context, afterPostID string, limit int) ([]string, error)
+	SetPostAuthor(ctx context.Context, postID string, authorID string) error
+	GetNewPosts(ctx context.Context, userID string, filter NewPostsFilter) ([]PostTimeline, error)
 }
 
 type UserTimeline struct {
@@ -27,6 +33,7 @@ type UserTimeline struct {
 
 type PostTimeline struct {
 	PostID      string
+	AuthorID    string
 	PublishedAt time.Time
 }
 
@@ -36,9 +43,19 @@ type TimelineFilter struct {
 	Page     int
 }
 
+// NewPostsFilter selects the posts published after Since, newest first. SinceID breaks
+// ties between posts published at the same time, posts with a greater ID are newer.
+type NewPostsFilter struct {
+	Since             time.Time
+	SinceID           string
+	ExcludedAuthorIDs []string
+	Limit             int
+}
+
 func CreateTimelinePostFromPost(post posts.Post) PostTimeline {
 	return PostTimeline{
 		PostID:      post.ID,
+		AuthorID:    post.AuthorID,
 		PublishedAt: post.PublishedAt,
 	}
 }
@@ -0,0 +1,24 @@
+package users
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrUpstreamUnavailable = errors.New("users.upstream_unavailable")
+)
+
+//go:generate mockery --name=UserProfileRepository --filename=mocks_user_profile_repository.go --output=../../../mocks --outpkg=mocks
+type UserProfileRepository interface {
+	// MGetUserProfiles returns the profiles found keyed by user ID, unknown users are left out
+	MGetUserProfiles(ctx context.Context, userIDs []string) (map[string]UserProfile, error)
+}
+
+type UserProfile struct {
+	UserID      string
+	Username    string
+	DisplayName string
+	AvatarUrl   *string
+	Verified    bool
+}
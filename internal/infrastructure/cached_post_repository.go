@@ -0,0 +1,143 @@
+package infrastructure
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"sync/atomic"
+	"time"
+	"uala-timeline-service/internal/domain/posts"
+)
+
+var (
+	_ posts.PostRepository = (*CachedPostRepository)(nil)
+	_ posts.PostCache      = (*CachedPostRepository)(nil)
+)
+
+// PostCacheStore keeps the posts served by CachedPostRepository.
+type PostCacheStore interface {
+	GetPosts(ctx context.Context, postIDs []string) (map[string]posts.Post, error)
+	SetPosts(ctx context.Context, posts []posts.Post) error
+	DeletePosts(ctx context.Context, postIDs []string) error
+}
+
+// CachedPostRepository serves the posts of GetPostById and MGetPosts from the store, so the
+// fanout of a post to its followers fetches it once. The store failing falls back to the
+// repository.
+type CachedPostRepository struct {
+	repository posts.PostRepository
+	store      PostCacheStore
+	hits       atomic.Uint64
+	misses     atomic.Uint64
+}
+
+// PostCacheStats counts the posts served from the cache and the ones fetched from the
+// posts service since the start.
+type PostCacheStats struct {
+	Hits     uint64  `json:"hits"`
+	Misses   uint64  `json:"misses"`
+	HitRatio float64 `json:"hit_ratio"`
+}
+
+func NewCachedPostRepository(repository posts.PostRepository, store PostCacheStore) *CachedPostRepository {
+	return &CachedPostRepository{
+		repository: repository,
+		store:      store,
+	}
+}
+
+func (c *CachedPostRepository) GetPostById(ctx context.Context, id string) (*posts.Post, error) {
+	cached, err := c.store.GetPosts(ctx, []string{id})
+	if err != nil {
+		log.Err(err).Str("post_id", id).Msg("error getting post from cache")
+	}
+	if post, ok := cached[id]; ok {
+		c.hits.Add(1)
+		return &post, nil
+	}
+	c.misses.Add(1)
+
+	post, err := c.repository.GetPostById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setPosts(ctx, []posts.Post{*post})
+	return post, nil
+}
+
+// MGetPosts only fetches the posts missing on the cache, the not found and unavailable IDs
+// are the ones reported by the repository for them.
+func (c *CachedPostRepository) MGetPosts(ctx context.Context, postIDs []string) (*posts.MGetPostsResult, error) {
+	cached, err := c.store.GetPosts(ctx, postIDs)
+	if err != nil {
+		log.Err(err).Int("posts", len(postIDs)).Msg("error getting posts from cache")
+	}
+
+	missingIDs := make([]string, 0, len(postIDs))
+	for _, id := range postIDs {
+		if _, ok := cached[id]; !ok {
+			missingIDs = append(missingIDs, id)
+		}
+	}
+	c.hits.Add(uint64(len(postIDs) - len(missingIDs)))
+	c.misses.Add(uint64(len(missingIDs)))
+
+	result := &posts.MGetPostsResult{}
+	if len(missingIDs) > 0 {
+		result, err = c.repository.MGetPosts(ctx, missingIDs)
+		if err != nil {
+			return nil, err
+		}
+		c.setPosts(ctx, result.Posts)
+	}
+
+	found := make(map[string]posts.Post, len(cached)+len(result.Posts))
+	for id, post := range cached {
+		found[id] = post
+	}
+	for _, post := range result.Posts {
+		found[post.ID] = post
+	}
+
+	ordered := make([]posts.Post, 0, len(found))
+	for _, id := range postIDs {
+		if post, ok := found[id]; ok {
+			ordered = append(ordered, post)
+		}
+	}
+
+	return &posts.MGetPostsResult{
+		Posts:          ordered,
+		NotFoundIDs:    result.NotFoundIDs,
+		UnavailableIDs: result.UnavailableIDs,
+	}, nil
+}
+
+// GetAuthorPosts is not cached, backfills read a range of posts rarely read again.
+func (c *CachedPostRepository) GetAuthorPosts(ctx context.Context, authorID string, since time.Time) ([]posts.Post, error) {
+	return c.repository.GetAuthorPosts(ctx, authorID, since)
+}
+
+func (c *CachedPostRepository) Invalidate(ctx context.Context, postID string) error {
+	return c.store.DeletePosts(ctx, []string{postID})
+}
+
+func (c *CachedPostRepository) Stats() PostCacheStats {
+	stats := PostCacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+func (c *CachedPostRepository) setPosts(ctx context.Context, fetched []posts.Post) {
+	if len(fetched) == 0 {
+		return
+	}
+	if err := c.store.SetPosts(ctx, fetched); err != nil {
+		log.Err(err).Int("posts", len(fetched)).Msg("error setting posts on cache")
+	}
+}
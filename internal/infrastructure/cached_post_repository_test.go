@@ -0,0 +1,111 @@
+package infrastructure
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/mocks"
+)
+
+func TestCachedPostRepository_GetPostById(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		cache         *InmemPostCache
+		invalidate    bool
+		expectedCalls int
+		expectedStats PostCacheStats
+	}{
+		{
+			name:          "should fetch the post once",
+			cache:         NewInmemPostCache(10, time.Minute),
+			expectedCalls: 1,
+			expectedStats: PostCacheStats{Hits: 1, Misses: 1, HitRatio: 0.5},
+		},
+		{
+			name:          "should fetch the post again once invalidated",
+			cache:         NewInmemPostCache(10, time.Minute),
+			invalidate:    true,
+			expectedCalls: 2,
+			expectedStats: PostCacheStats{Hits: 0, Misses: 2, HitRatio: 0},
+		},
+		{
+			name:          "should fetch the post again once expired",
+			cache:         NewInmemPostCache(10, 0),
+			expectedCalls: 2,
+			expectedStats: PostCacheStats{Hits: 0, Misses: 2, HitRatio: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockPostRepo := mocks.NewPostRepository(t)
+			mockPostRepo.On("GetPostById", ctx, "post-123").Return(&posts.Post{ID: "post-123", AuthorID: "author-789"}, nil).Times(tt.expectedCalls)
+			repository := NewCachedPostRepository(mockPostRepo, tt.cache)
+
+			// Act
+			_, err := repository.GetPostById(ctx, "post-123")
+			assert.NoError(t, err)
+			if tt.invalidate {
+				assert.NoError(t, repository.Invalidate(ctx, "post-123"))
+			}
+			post, err := repository.GetPostById(ctx, "post-123")
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, "author-789", post.AuthorID)
+			assert.Equal(t, tt.expectedStats, repository.Stats())
+		})
+	}
+}
+
+func TestCachedPostRepository_MGetPosts(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	mockPostRepo := mocks.NewPostRepository(t)
+	mockPostRepo.On("MGetPosts", ctx, []string{"post-1", "post-2"}).Return(&posts.MGetPostsResult{
+		Posts: []posts.Post{{ID: "post-1"}, {ID: "post-2"}},
+	}, nil).Once()
+	mockPostRepo.On("MGetPosts", ctx, []string{"post-3", "post-4"}).Return(&posts.MGetPostsResult{
+		Posts:          []posts.Post{{ID: "post-3"}},
+		UnavailableIDs: []string{"post-4"},
+	}, nil).Once()
+	repository := NewCachedPostRepository(mockPostRepo, NewInmemPostCache(10, time.Minute))
+
+	// Act
+	_, err := repository.MGetPosts(ctx, []string{"post-1", "post-2"})
+	assert.NoError(t, err)
+	result, err := repository.MGetPosts(ctx, []string{"post-3", "post-2", "post-4", "post-1"})
+
+	// Assert
+	assert.NoError(t, err)
+	var postIDs []string
+	for _, post := range result.Posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	assert.Equal(t, []string{"post-3", "post-2", "post-1"}, postIDs)
+	assert.Equal(t, []string{"post-4"}, result.UnavailableIDs)
+	assert.Equal(t, PostCacheStats{Hits: 2, Misses: 4, HitRatio: 2.0 / 6}, repository.Stats())
+}
+
+func TestInmemPostCache_Eviction(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	cache := NewInmemPostCache(2, time.Minute)
+	_ = cache.SetPosts(ctx, []posts.Post{{ID: "post-1"}, {ID: "post-2"}})
+
+	// Act
+	_, _ = cache.GetPosts(ctx, []string{"post-1"})
+	_ = cache.SetPosts(ctx, []posts.Post{{ID: "post-3"}})
+	found, err := cache.GetPosts(ctx, []string{"post-1", "post-2", "post-3"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, found, "post-1")
+	assert.NotContains(t, found, "post-2")
+	assert.Contains(t, found, "post-3")
+}
@@ -0,0 +1,71 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"time"
+	"uala-timeline-service/internal/domain/relationships"
+)
+
+var _ relationships.RelationshipRepository = (*CachedRelationshipRepository)(nil)
+
+const defaultRelationshipCacheSize = 10000
+
+// CachedRelationshipRepository keeps the blocked and muted lists of up to size users in
+// memory for a ttl, so reading a timeline does not call the followers service every time.
+// The least recently used lists are evicted when it is full. Expired lists are kept until
+// they are evicted and served while the followers service is down.
+type CachedRelationshipRepository struct {
+	repository relationships.RelationshipRepository
+	ttl        time.Duration
+	mu         sync.Mutex
+	blocked    *lruCache[[]string]
+	muted      *lruCache[[]string]
+}
+
+func NewCachedRelationshipRepository(repository relationships.RelationshipRepository, ttl time.Duration, size int) *CachedRelationshipRepository {
+	if size <= 0 {
+		size = defaultRelationshipCacheSize
+	}
+	return &CachedRelationshipRepository{
+		repository: repository,
+		ttl:        ttl,
+		blocked:    newLRUCache[[]string](size),
+		muted:      newLRUCache[[]string](size),
+	}
+}
+
+func (c *CachedRelationshipRepository) GetBlockedAuthorIDs(ctx context.Context, userID string) ([]string, error) {
+	return c.get(ctx, c.blocked, userID, c.repository.GetBlockedAuthorIDs)
+}
+
+func (c *CachedRelationshipRepository) GetMutedAuthorIDs(ctx context.Context, userID string) ([]string, error) {
+	return c.get(ctx, c.muted, userID, c.repository.GetMutedAuthorIDs)
+}
+
+func (c *CachedRelationshipRepository) get(
+	ctx context.Context,
+	cache *lruCache[[]string],
+	userID string,
+	fetch func(ctx context.Context, userID string) ([]string, error),
+) ([]string, error) {
+	c.mu.Lock()
+	cached, ok := cache.get(userID)
+	c.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.value, nil
+	}
+
+	userIDs, err := fetch(ctx, userID)
+	if err != nil {
+		if ok {
+			return cached.value, nil
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	cache.set(userID, userIDs, time.Now().Add(c.ttl))
+	c.mu.Unlock()
+	return userIDs, nil
+}
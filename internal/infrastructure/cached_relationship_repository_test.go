@@ -0,0 +1,80 @@
+package infrastructure
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"uala-timeline-service/internal/domain/relationships"
+	"uala-timeline-service/mocks"
+)
+
+func TestCachedRelationshipRepository_GetBlockedAuthorIDs(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		ttl               time.Duration
+		size              int
+		userID            string
+		setupMocks        func(mockRelationshipRepo *mocks.RelationshipRepository)
+		expectedAuthorIDs []string
+		expectedError     error
+	}{
+		{
+			name:   "should serve the cached list before it expires",
+			ttl:    time.Minute,
+			size:   2,
+			userID: "user-1",
+			setupMocks: func(mockRelationshipRepo *mocks.RelationshipRepository) {
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", ctx, "user-1").Return([]string{"author-1"}, nil).Once()
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", ctx, "user-2").Return([]string{"author-2"}, nil).Once()
+			},
+			expectedAuthorIDs: []string{"author-1"},
+		},
+		{
+			name:   "should serve an expired list while the followers service is down",
+			ttl:    0,
+			size:   2,
+			userID: "user-1",
+			setupMocks: func(mockRelationshipRepo *mocks.RelationshipRepository) {
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", ctx, "user-1").Return([]string{"author-1"}, nil).Once()
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", ctx, "user-2").Return([]string{"author-2"}, nil).Once()
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", ctx, "user-1").Return(nil, relationships.ErrUpstreamUnavailable).Once()
+			},
+			expectedAuthorIDs: []string{"author-1"},
+		},
+		{
+			name:   "should fetch again a list evicted by more recent users",
+			ttl:    time.Minute,
+			size:   1,
+			userID: "user-1",
+			setupMocks: func(mockRelationshipRepo *mocks.RelationshipRepository) {
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", ctx, "user-1").Return([]string{"author-1"}, nil).Once()
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", ctx, "user-2").Return([]string{"author-2"}, nil).Once()
+				mockRelationshipRepo.On("GetBlockedAuthorIDs", ctx, "user-1").Return(nil, relationships.ErrUpstreamUnavailable).Once()
+			},
+			expectedError: relationships.ErrUpstreamUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockRelationshipRepo := mocks.NewRelationshipRepository(t)
+			tt.setupMocks(mockRelationshipRepo)
+			repository := NewCachedRelationshipRepository(mockRelationshipRepo, tt.ttl, tt.size)
+			for _, userID := range []string{"user-1", "user-2"} {
+				_, err := repository.GetBlockedAuthorIDs(ctx, userID)
+				assert.NoError(t, err)
+			}
+
+			// Act
+			authorIDs, err := repository.GetBlockedAuthorIDs(ctx, tt.userID)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expectedAuthorIDs, authorIDs)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"time"
+	"uala-timeline-service/internal/domain/users"
+)
+
+var _ users.UserProfileRepository = (*CachedUserProfileRepository)(nil)
+
+const defaultProfileCacheSize = 10000
+
+// CachedUserProfileRepository keeps up to size profiles in memory for a ttl, so the authors
+// of a timeline are only fetched from the users service when they are missing or expired.
+// The least recently used profiles are evicted when it is full. While the users service is
+// down the expired profiles are served, and the authors never cached are left out.
+type CachedUserProfileRepository struct {
+	repository users.UserProfileRepository
+	ttl        time.Duration
+	mu         sync.Mutex
+	profiles   *lruCache[users.UserProfile]
+}
+
+func NewCachedUserProfileRepository(repository users.UserProfileRepository, ttl time.Duration, size int) *CachedUserProfileRepository {
+	if size <= 0 {
+		size = defaultProfileCacheSize
+	}
+	return &CachedUserProfileRepository{
+		repository: repository,
+		ttl:        ttl,
+		profiles:   newLRUCache[users.UserProfile](size),
+	}
+}
+
+func (c *CachedUserProfileRepository) MGetUserProfiles(ctx context.Context, userIDs []string) (map[string]users.UserProfile, error) {
+	profiles := make(map[string]users.UserProfile, len(userIDs))
+	var missingIDs []string
+	now := time.Now()
+	c.mu.Lock()
+	for _, userID := range userIDs {
+		cached, ok := c.profiles.get(userID)
+		if ok && now.Before(cached.expiresAt) {
+			profiles[userID] = cached.value
+			continue
+		}
+		missingIDs = append(missingIDs, userID)
+	}
+	c.mu.Unlock()
+	if len(missingIDs) == 0 {
+		return profiles, nil
+	}
+
+	fetched, err := c.repository.MGetUserProfiles(ctx, missingIDs)
+	if err != nil {
+		return c.stale(profiles, missingIDs), nil
+	}
+
+	expiresAt := time.Now().Add(c.ttl)
+	c.mu.Lock()
+	for userID, profile := range fetched {
+		c.profiles.set(userID, profile, expiresAt)
+		profiles[userID] = profile
+	}
+	c.mu.Unlock()
+	return profiles, nil
+}
+
+func (c *CachedUserProfileRepository) stale(profiles map[string]users.UserProfile, missingIDs []string) map[string]users.UserProfile {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, userID := range missingIDs {
+		if cached, ok := c.profiles.get(userID); ok {
+			profiles[userID] = cached.value
+		}
+	}
+	return profiles
+}
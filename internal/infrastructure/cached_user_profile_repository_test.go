@@ -0,0 +1,95 @@
+package infrastructure
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"uala-timeline-service/internal/domain/users"
+	"uala-timeline-service/mocks"
+)
+
+func TestCachedUserProfileRepository_MGetUserProfiles(t *testing.T) {
+	ctx := context.Background()
+	ana := users.UserProfile{UserID: "user-1", Username: "ana"}
+	bob := users.UserProfile{UserID: "user-2", Username: "bob"}
+	carl := users.UserProfile{UserID: "user-3", Username: "carl"}
+
+	tests := []struct {
+		name             string
+		ttl              time.Duration
+		size             int
+		userIDs          []string
+		setupMocks       func(mockUserProfileRepo *mocks.UserProfileRepository)
+		expectedProfiles map[string]users.UserProfile
+	}{
+		{
+			name:    "should only fetch the profiles missing from the cache",
+			ttl:     time.Minute,
+			size:    3,
+			userIDs: []string{"user-1", "user-2", "user-3"},
+			setupMocks: func(mockUserProfileRepo *mocks.UserProfileRepository) {
+				mockUserProfileRepo.On("MGetUserProfiles", ctx, []string{"user-1"}).Return(map[string]users.UserProfile{"user-1": ana}, nil).Once()
+				mockUserProfileRepo.On("MGetUserProfiles", ctx, []string{"user-2"}).Return(map[string]users.UserProfile{"user-2": bob}, nil).Once()
+				mockUserProfileRepo.On("MGetUserProfiles", ctx, []string{"user-3"}).Return(map[string]users.UserProfile{"user-3": carl}, nil).Once()
+			},
+			expectedProfiles: map[string]users.UserProfile{"user-1": ana, "user-2": bob, "user-3": carl},
+		},
+		{
+			name:    "should serve expired profiles while the users service is down",
+			ttl:     0,
+			size:    2,
+			userIDs: []string{"user-1"},
+			setupMocks: func(mockUserProfileRepo *mocks.UserProfileRepository) {
+				mockUserProfileRepo.On("MGetUserProfiles", ctx, []string{"user-1"}).Return(map[string]users.UserProfile{"user-1": ana}, nil).Once()
+				mockUserProfileRepo.On("MGetUserProfiles", ctx, []string{"user-2"}).Return(map[string]users.UserProfile{"user-2": bob}, nil).Once()
+				mockUserProfileRepo.On("MGetUserProfiles", ctx, []string{"user-1"}).Return(nil, users.ErrUpstreamUnavailable).Once()
+			},
+			expectedProfiles: map[string]users.UserProfile{"user-1": ana},
+		},
+		{
+			name:    "should leave out the profiles never cached while the users service is down",
+			ttl:     0,
+			size:    2,
+			userIDs: []string{"user-1", "user-3"},
+			setupMocks: func(mockUserProfileRepo *mocks.UserProfileRepository) {
+				mockUserProfileRepo.On("MGetUserProfiles", ctx, []string{"user-1"}).Return(map[string]users.UserProfile{"user-1": ana}, nil).Once()
+				mockUserProfileRepo.On("MGetUserProfiles", ctx, []string{"user-2"}).Return(map[string]users.UserProfile{"user-2": bob}, nil).Once()
+				mockUserProfileRepo.On("MGetUserProfiles", ctx, []string{"user-1", "user-3"}).Return(nil, users.ErrUpstreamUnavailable).Once()
+			},
+			expectedProfiles: map[string]users.UserProfile{"user-1": ana},
+		},
+		{
+			name:    "should fetch again a profile evicted by more recent authors",
+			ttl:     time.Minute,
+			size:    1,
+			userIDs: []string{"user-1"},
+			setupMocks: func(mockUserProfileRepo *mocks.UserProfileRepository) {
+				mockUserProfileRepo.On("MGetUserProfiles", ctx, []string{"user-1"}).Return(map[string]users.UserProfile{"user-1": ana}, nil).Once()
+				mockUserProfileRepo.On("MGetUserProfiles", ctx, []string{"user-2"}).Return(map[string]users.UserProfile{"user-2": bob}, nil).Once()
+				mockUserProfileRepo.On("MGetUserProfiles", ctx, []string{"user-1"}).Return(nil, users.ErrUpstreamUnavailable).Once()
+			},
+			expectedProfiles: map[string]users.UserProfile{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockUserProfileRepo := mocks.NewUserProfileRepository(t)
+			tt.setupMocks(mockUserProfileRepo)
+			repository := NewCachedUserProfileRepository(mockUserProfileRepo, tt.ttl, tt.size)
+			for _, userID := range []string{"user-1", "user-2"} {
+				_, err := repository.MGetUserProfiles(ctx, []string{userID})
+				assert.NoError(t, err)
+			}
+
+			// Act
+			profiles, err := repository.MGetUserProfiles(ctx, tt.userIDs)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedProfiles, profiles)
+		})
+	}
+}
@@ -26,6 +26,8 @@ const (
 	dayPrefix = "timeline:"
 	pkPrefix  = "user:%s"
 	skPrefix  = "day:%s"
+
+	batchGetItemLimit = 100
 )
 
 type DynamoDayTimelineFilledRepository struct {
@@ -40,36 +42,74 @@ func NewDynamoPaymentRepository(client *dynamodb.Client, tableName string) *Dyna
 	}
 }
 
+// GetDayUserTimelineFilled reads every day snapshot between the filter days, when the
+// filter has no end day only the first day is read. Days without snapshot are reported on
+// MissingDays.
 func (d *DynamoDayTimelineFilledRepository) GetDayUserTimelineFilled(ctx context.Context, filter day_timeline_filled.DayUserTimelineFilledFilter) (*day_timeline_filled.DayUserTimelineFilled, error) {
-	dayKey := fmt.Sprintf("%v:%v:%v", filter.FromYear, filter.FromMonth, filter.FromDay)
-	input := &dynamodb.GetItemInput{
-		TableName: aws.String(d.tableName),
-		Key: map[string]types.AttributeValue{
-			"pk": &types.AttributeValueMemberS{Value: buildPK(filter.UserID)},
-			"sk": &types.AttributeValueMemberS{Value: buildSK(dayKey)},
-		},
-	}
+	days := filter.Days()
+	dayKeys := make([]string, len(days))
+	for i, day := range days {
+		dayKeys[i] = buildDateKey(day)
+	}
+	pagesBySK := make(map[string]DynamoDayUserTimelinePage, len(dayKeys))
+	for start := 0; start < len(dayKeys); start += batchGetItemLimit {
+		end := min(start+batchGetItemLimit, len(dayKeys))
+		keys := make([]map[string]types.AttributeValue, 0, end-start)
+		for _, dayKey := range dayKeys[start:end] {
+			keys = append(keys, map[string]types.AttributeValue{
+				"pk": &types.AttributeValueMemberS{Value: buildPK(filter.UserID)},
+				"sk": &types.AttributeValueMemberS{Value: buildSK(dayKey)},
+			})
+		}
 
-	result, err := d.client.GetItem(ctx, input)
-	if err != nil {
-		log.Err(err).Msg("error getting timelinefilled from dynamo")
-		return nil, err
+		requestItems := map[string]types.KeysAndAttributes{
+			d.tableName: {Keys: keys},
+		}
+		for len(requestItems) > 0 {
+			result, err := d.client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+				RequestItems: requestItems,
+			})
+			if err != nil {
+				log.Err(err).Msg("error getting timelinefilled from dynamo")
+				return nil, err
+			}
+
+			for _, item := range result.Responses[d.tableName] {
+				var dayTimeline DynamoDayUserTimelinePage
+				err = attributevalue.UnmarshalMap(item, &dayTimeline)
+				if err != nil {
+					return nil, err
+				}
+				pagesBySK[dayTimeline.SK] = dayTimeline
+			}
+			requestItems = result.UnprocessedKeys
+		}
 	}
 
-	if len(result.Item) == 0 {
-		return &day_timeline_filled.DayUserTimelineFilled{
-			Posts:  nil,
-			UserID: filter.UserID,
-		}, nil
+	timelineFilled := &day_timeline_filled.DayUserTimelineFilled{
+		Posts:       nil,
+		UserID:      filter.UserID,
+		DayVersions: make(map[string]time.Time, len(pagesBySK)),
 	}
+	for i, dayKey := range dayKeys {
+		dayTimeline, ok := pagesBySK[buildSK(dayKey)]
+		if !ok {
+			timelineFilled.MissingDays = append(timelineFilled.MissingDays, days[i])
+			continue
+		}
 
-	var dayTimeline DynamoDayUserTimelinePage
-	err = attributevalue.UnmarshalMap(result.Item, &dayTimeline)
-	if err != nil {
-		return nil, err
+		dayTimelineFilled, err := dayTimeline.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		timelineFilled.Posts = append(timelineFilled.Posts, dayTimelineFilled.Posts...)
+		timelineFilled.DayVersions[dayKey] = dayTimelineFilled.LastUpdate
+		if dayTimelineFilled.LastUpdate.After(timelineFilled.LastUpdate) {
+			timelineFilled.LastUpdate = dayTimelineFilled.LastUpdate
+		}
 	}
 
-	return dayTimeline.toDomain()
+	return timelineFilled, nil
 }
 
 func (d *DynamoDayTimelineFilledRepository) AddPosts(ctx context.Context, userID string, post []posts.Post) error {
@@ -144,7 +184,12 @@ func (d *DynamoDayTimelineFilledRepository) UpdatePosts(ctx context.Context, use
 		}
 
 		if decompressedPost.ID == post.ID {
+			// Last writer wins, an older version must not overwrite a newer one
+			if !decompressedPost.UpdatedAt.Before(post.UpdatedAt) {
+				return nil
+			}
 			dayTimeline.Posts[i] = newPostCompressed
+			dayTimeline.LastUpdate = time.Now()
 			break
 		}
 	}
@@ -200,12 +245,30 @@ func (d *DynamoDayTimelineFilledRepository) getDayFilled(ctx context.Context, us
 }
 
 func (d *DynamoDayTimelineFilledRepository) RemovePost(ctx context.Context, userID string, post *posts.Post) error {
-	dayKey := buildDateKeyByPost(*post)
+	return d.RemovePosts(ctx, userID, []posts.Post{*post})
+}
+
+func (d *DynamoDayTimelineFilledRepository) RemovePosts(ctx context.Context, userID string, removedPosts []posts.Post) error {
+	for dayKey, dayPosts := range splitPostByDate(removedPosts) {
+		err := d.removeDayPosts(ctx, userID, dayKey, dayPosts)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *DynamoDayTimelineFilledRepository) removeDayPosts(ctx context.Context, userID string, dayKey string, removedPosts []posts.Post) error {
 	dayTimeline, err := d.getDayFilled(ctx, userID, dayKey)
 	if err != nil {
 		return err
 	}
 
+	removedIDs := make(map[string]struct{}, len(removedPosts))
+	for _, post := range removedPosts {
+		removedIDs[post.ID] = struct{}{}
+	}
+
 	var newPosts []string
 	postFound := false
 	for _, compressedPost := range dayTimeline.Posts {
@@ -214,7 +277,7 @@ func (d *DynamoDayTimelineFilledRepository) RemovePost(ctx context.Context, user
 			return err
 		}
 
-		if decompressedPost.ID == post.ID {
+		if _, ok := removedIDs[decompressedPost.ID]; ok {
 			postFound = true
 			continue
 		}
@@ -335,8 +398,13 @@ func splitPostByDate(domainPosts []posts.Post) map[string][]posts.Post {
 	return postsMapByDay
 }
 
+// buildDateKeyByPost buckets the post on its UTC day, whatever the zone it was parsed with.
 func buildDateKeyByPost(post posts.Post) string {
-	return fmt.Sprintf("%v:%v:%v", post.PublishedAt.Year(), int(post.PublishedAt.Month()), post.PublishedAt.Day())
+	return buildDateKey(post.PublishedAt.UTC())
+}
+
+func buildDateKey(day time.Time) string {
+	return fmt.Sprintf("%v:%v:%v", day.Year(), int(day.Month()), day.Day())
 }
 
 func buildPK(userId string) string {
@@ -0,0 +1,88 @@
+package infrastructure
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"uala-timeline-service/internal/domain/posts"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestCompressPost(t *testing.T) {
+	publishedAt := time.Date(2025, 5, 21, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		post posts.Post
+	}{
+		{
+			name: "should keep text posts",
+			post: posts.Post{
+				ID:          "post-123",
+				AuthorID:    "author-789",
+				Contents:    []posts.Content{{Type: posts.ContentTypeText, Text: stringPtr("hello")}},
+				PublishedAt: publishedAt,
+				UpdatedAt:   publishedAt,
+			},
+		},
+		{
+			name: "should keep rich contents",
+			post: posts.Post{
+				ID:       "post-123",
+				AuthorID: "author-789",
+				Contents: []posts.Content{
+					{
+						Type:  posts.ContentTypeVideo,
+						Url:   stringPtr("https://cdn/clip.mp4"),
+						Media: &posts.Media{MimeType: "video/mp4", Width: 1280, Height: 720, DurationMs: 15000, ThumbnailUrl: stringPtr("https://cdn/clip.png")},
+					},
+					{
+						Type:    posts.ContentTypeLink,
+						Url:     stringPtr("https://blog/post"),
+						Preview: &posts.LinkPreview{Title: stringPtr("A post"), ImageUrl: stringPtr("https://blog/post.png")},
+					},
+					{Type: posts.ContentTypeMention, Text: stringPtr("@ana"), MentionedUserID: stringPtr("user-42")},
+					{Type: "poll", Data: json.RawMessage(`{"type":"poll","options":["yes","no"]}`)},
+				},
+				PublishedAt: publishedAt,
+				UpdatedAt:   publishedAt,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			compressed, err := compressPost(tt.post)
+			assert.NoError(t, err)
+			decompressed, err := decompressPost(compressed)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.post, *decompressed)
+		})
+	}
+}
+
+func TestDecompressPost_StoredBeforeRichContents(t *testing.T) {
+	// Setup
+	stored := `{"ID":"post-123","Contents":[{"Type":"image","Text":null,"Url":"https://cdn/img.png"}],"AuthorID":"author-789","PublishedAt":"2025-05-21T10:00:00Z","UpdatedAt":"2025-05-21T10:00:00Z"}`
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	_, _ = gzipWriter.Write([]byte(stored))
+	_ = gzipWriter.Close()
+
+	// Act
+	post, err := decompressPost(base64.StdEncoding.EncodeToString(compressed.Bytes()))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []posts.Content{{Type: posts.ContentTypeImage, Url: stringPtr("https://cdn/img.png")}}, post.Contents)
+}
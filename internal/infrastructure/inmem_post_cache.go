@@ -0,0 +1,92 @@
+package infrastructure
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+	"uala-timeline-service/internal/domain/posts"
+)
+
+var _ PostCacheStore = (*InmemPostCache)(nil)
+
+// InmemPostCache keeps up to size posts of the replica for a ttl, evicting the least
+// recently used one when it is full.
+type InmemPostCache struct {
+	size    int
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type inmemPostCacheEntry struct {
+	post      posts.Post
+	expiresAt time.Time
+}
+
+func NewInmemPostCache(size int, ttl time.Duration) *InmemPostCache {
+	return &InmemPostCache{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (c *InmemPostCache) GetPosts(ctx context.Context, postIDs []string) (map[string]posts.Post, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	found := make(map[string]posts.Post, len(postIDs))
+	for _, id := range postIDs {
+		element, ok := c.entries[id]
+		if !ok {
+			continue
+		}
+		entry := element.Value.(*inmemPostCacheEntry)
+		if !now.Before(entry.expiresAt) {
+			c.order.Remove(element)
+			delete(c.entries, id)
+			continue
+		}
+		c.order.MoveToFront(element)
+		found[id] = entry.post
+	}
+	return found, nil
+}
+
+func (c *InmemPostCache) SetPosts(ctx context.Context, posts []posts.Post) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+	for _, post := range posts {
+		if element, ok := c.entries[post.ID]; ok {
+			element.Value = &inmemPostCacheEntry{post: post, expiresAt: expiresAt}
+			c.order.MoveToFront(element)
+			continue
+		}
+		c.entries[post.ID] = c.order.PushFront(&inmemPostCacheEntry{post: post, expiresAt: expiresAt})
+		if c.order.Len() > c.size {
+			oldest := c.order.Back()
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*inmemPostCacheEntry).post.ID)
+		}
+	}
+	return nil
+}
+
+func (c *InmemPostCache) DeletePosts(ctx context.Context, postIDs []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, id := range postIDs {
+		if element, ok := c.entries[id]; ok {
+			c.order.Remove(element)
+			delete(c.entries, id)
+		}
+	}
+	return nil
+}
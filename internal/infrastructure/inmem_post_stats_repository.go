@@ -0,0 +1,84 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"time"
+	"uala-timeline-service/internal/domain/post_stats"
+)
+
+var _ post_stats.PostStatsRepository = (*InmemPostStatsRepository)(nil)
+
+type InmemPostStatsRepository struct {
+	mu          sync.RWMutex
+	engagements map[engagementKey]engagementState
+	stats       map[string]post_stats.PostStats
+}
+
+type engagementKey struct {
+	postID string
+	kind   string
+	id     string
+}
+
+// engagementState is a tombstone when removedAt is set.
+type engagementState struct {
+	addedAt   time.Time
+	removedAt time.Time
+}
+
+func NewInmemPostStatsRepository() *InmemPostStatsRepository {
+	return &InmemPostStatsRepository{
+		engagements: make(map[engagementKey]engagementState),
+		stats:       make(map[string]post_stats.PostStats),
+	}
+}
+
+func (i *InmemPostStatsRepository) AddEngagement(ctx context.Context, engagement post_stats.Engagement) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	key := engagementKey{postID: engagement.PostID, kind: engagement.Kind, id: engagement.ID}
+	if state, ok := i.engagements[key]; ok && (state.removedAt.IsZero() || !state.removedAt.Before(engagement.At)) {
+		return nil
+	}
+	i.engagements[key] = engagementState{addedAt: engagement.At}
+	i.stats[engagement.PostID] = i.postStats(engagement.PostID).Add(engagement.Kind, 1)
+	return nil
+}
+
+func (i *InmemPostStatsRepository) RemoveEngagement(ctx context.Context, engagement post_stats.Engagement) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	key := engagementKey{postID: engagement.PostID, kind: engagement.Kind, id: engagement.ID}
+	state, ok := i.engagements[key]
+	switch {
+	case !ok:
+		i.engagements[key] = engagementState{addedAt: engagement.At, removedAt: engagement.At}
+	case state.removedAt.IsZero() && !state.addedAt.After(engagement.At):
+		i.engagements[key] = engagementState{addedAt: state.addedAt, removedAt: engagement.At}
+		i.stats[engagement.PostID] = i.postStats(engagement.PostID).Add(engagement.Kind, -1)
+	case !state.removedAt.IsZero() && state.removedAt.Before(engagement.At):
+		i.engagements[key] = engagementState{addedAt: state.addedAt, removedAt: engagement.At}
+	}
+	return nil
+}
+
+func (i *InmemPostStatsRepository) MGetPostStats(ctx context.Context, postIDs []string) (map[string]post_stats.PostStats, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	stats := make(map[string]post_stats.PostStats, len(postIDs))
+	for _, postID := range postIDs {
+		if postStats, ok := i.stats[postID]; ok {
+			stats[postID] = postStats
+		}
+	}
+	return stats, nil
+}
+
+func (i *InmemPostStatsRepository) postStats(postID string) post_stats.PostStats {
+	postStats, ok := i.stats[postID]
+	if !ok {
+		postStats = post_stats.PostStats{PostID: postID}
+	}
+	return postStats
+}
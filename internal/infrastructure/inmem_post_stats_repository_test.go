@@ -0,0 +1,42 @@
+package infrastructure
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"uala-timeline-service/internal/domain/post_stats"
+)
+
+func TestInmemPostStatsRepository(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	at := time.Date(2025, 5, 21, 12, 0, 0, 0, time.UTC)
+	repository := NewInmemPostStatsRepository()
+	like := post_stats.Engagement{PostID: "post-123", Kind: post_stats.EngagementLike, ID: "user-1", UserID: "user-1", At: at}
+	otherLike := post_stats.Engagement{PostID: "post-123", Kind: post_stats.EngagementLike, ID: "user-2", UserID: "user-2", At: at}
+	reply := post_stats.Engagement{PostID: "post-123", Kind: post_stats.EngagementReply, ID: "post-789", UserID: "user-1", At: at}
+	lateRepost := post_stats.Engagement{PostID: "post-456", Kind: post_stats.EngagementRepost, ID: "user-1", UserID: "user-1", At: at}
+	repostUndo := lateRepost
+	repostUndo.At = at.Add(time.Minute)
+	staleLikeUndo := like
+	staleLikeUndo.At = at.Add(-time.Minute)
+
+	// Act
+	assert.NoError(t, repository.AddEngagement(ctx, like))
+	assert.NoError(t, repository.AddEngagement(ctx, like))
+	assert.NoError(t, repository.AddEngagement(ctx, otherLike))
+	assert.NoError(t, repository.AddEngagement(ctx, reply))
+	assert.NoError(t, repository.RemoveEngagement(ctx, otherLike))
+	assert.NoError(t, repository.RemoveEngagement(ctx, otherLike))
+	assert.NoError(t, repository.RemoveEngagement(ctx, staleLikeUndo))
+	assert.NoError(t, repository.RemoveEngagement(ctx, repostUndo))
+	assert.NoError(t, repository.AddEngagement(ctx, lateRepost))
+	stats, err := repository.MGetPostStats(ctx, []string{"post-123", "post-456"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]post_stats.PostStats{
+		"post-123": {PostID: "post-123", Likes: 1, Replies: 1},
+	}, stats)
+}
@@ -0,0 +1,61 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"uala-timeline-service/internal/domain/read_markers"
+)
+
+var _ read_markers.ReadMarkerRepository = (*InmemReadMarkerRepository)(nil)
+
+type InmemReadMarkerRepository struct {
+	mu      sync.RWMutex
+	markers map[string]map[string]read_markers.ReadMarker
+}
+
+func NewInmemReadMarkerRepository() *InmemReadMarkerRepository {
+	return &InmemReadMarkerRepository{
+		markers: make(map[string]map[string]read_markers.ReadMarker),
+	}
+}
+
+func (i *InmemReadMarkerRepository) GetReadMarker(ctx context.Context, userID string, deviceID string) (*read_markers.ReadMarker, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	marker, ok := i.markers[userID][deviceID]
+	if !ok {
+		return nil, read_markers.ErrReadMarkerNotFound
+	}
+	return &marker, nil
+}
+
+func (i *InmemReadMarkerRepository) GetLatestReadMarker(ctx context.Context, userID string) (*read_markers.ReadMarker, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var latest *read_markers.ReadMarker
+	for _, marker := range i.markers[userID] {
+		if latest == nil || marker.IsAfter(*latest) {
+			latest = &marker
+		}
+	}
+	if latest == nil {
+		return nil, read_markers.ErrReadMarkerNotFound
+	}
+	return latest, nil
+}
+
+func (i *InmemReadMarkerRepository) SaveReadMarker(ctx context.Context, marker read_markers.ReadMarker) (*read_markers.ReadMarker, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.markers[marker.UserID] == nil {
+		i.markers[marker.UserID] = make(map[string]read_markers.ReadMarker)
+	}
+	if stored, ok := i.markers[marker.UserID][marker.DeviceID]; ok && !marker.IsAfter(stored) {
+		return &stored, nil
+	}
+	i.markers[marker.UserID][marker.DeviceID] = marker
+	return &marker, nil
+}
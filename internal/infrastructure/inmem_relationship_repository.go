@@ -0,0 +1,46 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"uala-timeline-service/internal/domain/relationships"
+)
+
+var _ relationships.RelationshipRepository = (*InmemRelationshipRepository)(nil)
+
+type InmemRelationshipRepository struct {
+	mu      sync.RWMutex
+	blocked map[string][]string
+	muted   map[string][]string
+}
+
+func NewInmemRelationshipRepository() *InmemRelationshipRepository {
+	return &InmemRelationshipRepository{
+		blocked: make(map[string][]string),
+		muted:   make(map[string][]string),
+	}
+}
+
+func (i *InmemRelationshipRepository) GetBlockedAuthorIDs(ctx context.Context, userID string) ([]string, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return append([]string{}, i.blocked[userID]...), nil
+}
+
+func (i *InmemRelationshipRepository) GetMutedAuthorIDs(ctx context.Context, userID string) ([]string, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return append([]string{}, i.muted[userID]...), nil
+}
+
+func (i *InmemRelationshipRepository) Block(userID string, authorID string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.blocked[userID] = append(i.blocked[userID], authorID)
+}
+
+func (i *InmemRelationshipRepository) Mute(userID string, authorID string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.muted[userID] = append(i.muted[userID], authorID)
+}
@@ -0,0 +1,38 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"uala-timeline-service/internal/domain/users"
+)
+
+var _ users.UserProfileRepository = (*InmemUserProfileRepository)(nil)
+
+type InmemUserProfileRepository struct {
+	mu       sync.RWMutex
+	profiles map[string]users.UserProfile
+}
+
+func NewInmemUserProfileRepository() *InmemUserProfileRepository {
+	return &InmemUserProfileRepository{
+		profiles: make(map[string]users.UserProfile),
+	}
+}
+
+func (i *InmemUserProfileRepository) MGetUserProfiles(ctx context.Context, userIDs []string) (map[string]users.UserProfile, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	profiles := make(map[string]users.UserProfile, len(userIDs))
+	for _, userID := range userIDs {
+		if profile, ok := i.profiles[userID]; ok {
+			profiles[userID] = profile
+		}
+	}
+	return profiles, nil
+}
+
+func (i *InmemUserProfileRepository) Save(profile users.UserProfile) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.profiles[profile.UserID] = profile
+}
@@ -0,0 +1,57 @@
+package infrastructure
+
+import (
+	"container/list"
+	"time"
+)
+
+// lruCache keeps up to size entries and evicts the least recently used one when it is full.
+// Expired entries are kept until they are evicted, so callers can serve them while the
+// upstream service is down. It is not safe for concurrent use.
+type lruCache[V any] struct {
+	entries map[string]*list.Element
+	order   *list.List
+	size    int
+}
+
+type lruEntry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+func newLRUCache[V any](size int) *lruCache[V] {
+	return &lruCache[V]{
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		size:    size,
+	}
+}
+
+func (c *lruCache[V]) get(key string) (*lruEntry[V], bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*lruEntry[V]), true
+}
+
+func (c *lruCache[V]) set(key string, value V, expiresAt time.Time) {
+	entry := &lruEntry[V]{
+		key:       key,
+		value:     value,
+		expiresAt: expiresAt,
+	}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[V]).key)
+	}
+}
@@ -0,0 +1,186 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+	"time"
+	"uala-timeline-service/internal/domain/post_stats"
+)
+
+// addEngagementRow only counts the engagement when it was not stored yet, so an event
+// published twice does not move the counters, or when it was taken back before the add, so an add
+// delivered after its undo is ignored.
+var addEngagementRow = `
+        WITH added AS (
+            INSERT INTO post_engagements (post_id, kind, engagement_id, user_id, created_at)
+            VALUES ($1, $2, $3, $4, $5)
+            ON CONFLICT (post_id, kind, engagement_id) DO UPDATE
+            SET user_id = EXCLUDED.user_id,
+                created_at = EXCLUDED.created_at,
+                removed_at = NULL
+            WHERE post_engagements.removed_at < EXCLUDED.created_at
+            RETURNING post_id, kind
+        )
+        INSERT INTO post_stats (post_id, likes, reposts, replies, updated_at)
+        SELECT post_id, (kind = 'like')::int, (kind = 'repost')::int, (kind = 'reply')::int, $6
+        FROM added
+        ON CONFLICT (post_id) DO UPDATE
+        SET likes = post_stats.likes + EXCLUDED.likes,
+            reposts = post_stats.reposts + EXCLUDED.reposts,
+            replies = post_stats.replies + EXCLUDED.replies,
+            updated_at = EXCLUDED.updated_at
+    `
+
+// removeEngagementRow keeps the engagement as a tombstone with the undo time. The counters
+// only move when a counted engagement added at or before the undo is taken back, unknown
+// engagements are stored as tombstones so their late add is ignored.
+var removeEngagementRow = `
+        WITH previous AS (
+            SELECT removed_at
+            FROM post_engagements
+            WHERE post_id = $1 AND kind = $2 AND engagement_id = $3
+            FOR UPDATE
+        ), removed AS (
+            INSERT INTO post_engagements (post_id, kind, engagement_id, user_id, created_at, removed_at)
+            VALUES ($1, $2, $3, $4, $5, $5)
+            ON CONFLICT (post_id, kind, engagement_id) DO UPDATE
+            SET removed_at = EXCLUDED.removed_at
+            WHERE (post_engagements.removed_at IS NULL AND post_engagements.created_at <= EXCLUDED.removed_at)
+               OR post_engagements.removed_at < EXCLUDED.removed_at
+            RETURNING post_id, kind
+        )
+        UPDATE post_stats
+        SET likes = post_stats.likes - (removed.kind = 'like')::int,
+            reposts = post_stats.reposts - (removed.kind = 'repost')::int,
+            replies = post_stats.replies - (removed.kind = 'reply')::int,
+            updated_at = $6
+        FROM removed, previous
+        WHERE post_stats.post_id = removed.post_id AND previous.removed_at IS NULL
+    `
+
+var mGetPostStatsRows = `
+        SELECT post_id, likes, reposts, replies
+        FROM post_stats
+        WHERE post_id = ANY($1)
+    `
+
+// countEngagementsByAuthorRows reads the authors from the timeline of the user, engagements
+// do not carry the post author.
+var countEngagementsByAuthorRows = `
+        SELECT t.author_id, COUNT(*) AS engagements
+        FROM post_engagements e
+        JOIN timelines t ON t.user_id = e.user_id AND t.post_id = e.post_id
+        WHERE e.user_id = $1 AND e.removed_at IS NULL AND t.author_id = ANY($2)
+        GROUP BY t.author_id
+    `
+
+var (
+	_ post_stats.PostStatsRepository        = (*PostStatsRepository)(nil)
+	_ post_stats.AuthorEngagementRepository = (*PostStatsRepository)(nil)
+)
+
+type PostStatsRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostStatsRepository(db *sqlx.DB) *PostStatsRepository {
+	return &PostStatsRepository{db: db}
+}
+
+func (r *PostStatsRepository) AddEngagement(ctx context.Context, engagement post_stats.Engagement) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		addEngagementRow,
+		engagement.PostID,
+		engagement.Kind,
+		engagement.ID,
+		engagement.UserID,
+		engagement.At,
+		time.Now(),
+	)
+	if err != nil {
+		log.Err(err).Msg("error adding post engagement on postgres")
+		return fmt.Errorf("error adding post engagement: %w", err)
+	}
+	return nil
+}
+
+func (r *PostStatsRepository) RemoveEngagement(ctx context.Context, engagement post_stats.Engagement) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		removeEngagementRow,
+		engagement.PostID,
+		engagement.Kind,
+		engagement.ID,
+		engagement.UserID,
+		engagement.At,
+		time.Now(),
+	)
+	if err != nil {
+		log.Err(err).Msg("error removing post engagement on postgres")
+		return fmt.Errorf("error removing post engagement: %w", err)
+	}
+	return nil
+}
+
+func (r *PostStatsRepository) MGetPostStats(ctx context.Context, postIDs []string) (map[string]post_stats.PostStats, error) {
+	if len(postIDs) == 0 {
+		return map[string]post_stats.PostStats{}, nil
+	}
+
+	var rows []postStatsRow
+	err := r.db.SelectContext(ctx, &rows, mGetPostStatsRows, pq.Array(postIDs))
+	if err != nil {
+		log.Err(err).Msg("error getting post stats from postgres")
+		return nil, fmt.Errorf("error getting post stats: %w", err)
+	}
+
+	stats := make(map[string]post_stats.PostStats, len(rows))
+	for _, row := range rows {
+		stats[row.PostID] = row.toDomain()
+	}
+	return stats, nil
+}
+
+func (r *PostStatsRepository) CountEngagementsByAuthor(ctx context.Context, userID string, authorIDs []string) (map[string]int, error) {
+	if len(authorIDs) == 0 {
+		return map[string]int{}, nil
+	}
+
+	var rows []authorEngagementsRow
+	err := r.db.SelectContext(ctx, &rows, countEngagementsByAuthorRows, userID, pq.Array(authorIDs))
+	if err != nil {
+		log.Err(err).Msg("error counting author engagements from postgres")
+		return nil, fmt.Errorf("error counting author engagements: %w", err)
+	}
+
+	engagements := make(map[string]int, len(rows))
+	for _, row := range rows {
+		engagements[row.AuthorID] = row.Engagements
+	}
+	return engagements, nil
+}
+
+type authorEngagementsRow struct {
+	AuthorID    string `db:"author_id"`
+	Engagements int    `db:"engagements"`
+}
+
+type postStatsRow struct {
+	PostID  string `db:"post_id"`
+	Likes   int    `db:"likes"`
+	Reposts int    `db:"reposts"`
+	Replies int    `db:"replies"`
+}
+
+func (r *postStatsRow) toDomain() post_stats.PostStats {
+	return post_stats.PostStats{
+		PostID:  r.PostID,
+		Likes:   r.Likes,
+		Reposts: r.Reposts,
+		Replies: r.Replies,
+	}
+}
@@ -0,0 +1,107 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+	"time"
+	"uala-timeline-service/internal/domain/read_markers"
+)
+
+var getReadMarkerRow = `
+        SELECT user_id, device_id, post_id, published_at, updated_at
+        FROM read_markers
+        WHERE user_id = $1 AND device_id = $2
+    `
+
+var getLatestReadMarkerRow = `
+        SELECT user_id, device_id, post_id, published_at, updated_at
+        FROM read_markers
+        WHERE user_id = $1
+        ORDER BY published_at DESC, post_id DESC
+        LIMIT 1
+    `
+
+// saveReadMarkerRow keeps the stored marker when it is newer, the row returned is the
+// one kept in both cases.
+var saveReadMarkerRow = `
+        WITH saved AS (
+            INSERT INTO read_markers (user_id, device_id, post_id, published_at, updated_at)
+            VALUES ($1, $2, $3, $4, $5)
+            ON CONFLICT (user_id, device_id) DO UPDATE
+            SET post_id = EXCLUDED.post_id, published_at = EXCLUDED.published_at, updated_at = EXCLUDED.updated_at
+            WHERE (EXCLUDED.published_at, EXCLUDED.post_id) > (read_markers.published_at, read_markers.post_id)
+            RETURNING user_id, device_id, post_id, published_at, updated_at
+        )
+        SELECT user_id, device_id, post_id, published_at, updated_at FROM saved
+        UNION ALL
+        SELECT user_id, device_id, post_id, published_at, updated_at
+        FROM read_markers
+        WHERE user_id = $1 AND device_id = $2 AND NOT EXISTS (SELECT 1 FROM saved)
+    `
+
+var _ read_markers.ReadMarkerRepository = (*ReadMarkerRepository)(nil)
+
+type ReadMarkerRepository struct {
+	db *sqlx.DB
+}
+
+func NewReadMarkerRepository(db *sqlx.DB) *ReadMarkerRepository {
+	return &ReadMarkerRepository{db: db}
+}
+
+func (r *ReadMarkerRepository) GetReadMarker(ctx context.Context, userID string, deviceID string) (*read_markers.ReadMarker, error) {
+	return r.getReadMarker(ctx, getReadMarkerRow, userID, deviceID)
+}
+
+func (r *ReadMarkerRepository) GetLatestReadMarker(ctx context.Context, userID string) (*read_markers.ReadMarker, error) {
+	return r.getReadMarker(ctx, getLatestReadMarkerRow, userID)
+}
+
+func (r *ReadMarkerRepository) SaveReadMarker(ctx context.Context, marker read_markers.ReadMarker) (*read_markers.ReadMarker, error) {
+	var row readMarkerRow
+	err := r.db.GetContext(ctx, &row, saveReadMarkerRow, marker.UserID, marker.DeviceID, marker.PostID, marker.PublishedAt, marker.UpdatedAt)
+	if err != nil {
+		log.Err(err).Msg("error saving read marker on postgres")
+		return nil, fmt.Errorf("error saving read marker: %w", err)
+	}
+
+	savedMarker := row.toDomain()
+	return &savedMarker, nil
+}
+
+func (r *ReadMarkerRepository) getReadMarker(ctx context.Context, query string, args ...interface{}) (*read_markers.ReadMarker, error) {
+	var row readMarkerRow
+	err := r.db.GetContext(ctx, &row, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, read_markers.ErrReadMarkerNotFound
+		}
+		log.Err(err).Msg("error getting read marker from postgres")
+		return nil, fmt.Errorf("error getting read marker: %w", err)
+	}
+
+	marker := row.toDomain()
+	return &marker, nil
+}
+
+type readMarkerRow struct {
+	UserID      string    `db:"user_id"`
+	DeviceID    string    `db:"device_id"`
+	PostID      string    `db:"post_id"`
+	PublishedAt time.Time `db:"published_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+func (r *readMarkerRow) toDomain() read_markers.ReadMarker {
+	return read_markers.ReadMarker{
+		UserID:      r.UserID,
+		DeviceID:    r.DeviceID,
+		PostID:      r.PostID,
+		PublishedAt: r.PublishedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/huandu/go-sqlbuilder"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog/log"
 	"time"
 	"uala-timeline-service/internal/domain/timeline"
@@ -15,11 +16,45 @@ import (
 var getPostTimelineRow = `
         SELECT 
            post_id,
+           author_id,
            published_at
         FROM timelines
         WHERE post_id = $1 AND user_id = $2
     `
 
+var removePostTimelineRow = `
+        DELETE FROM timelines
+        WHERE user_id = $1 AND post_id = $2
+    `
+
+var getAuthorPostsRows = `
+        SELECT
+           post_id,
+           author_id,
+           published_at
+        FROM timelines
+        WHERE user_id = $1 AND author_id = $2
+    `
+
+var removePostsRows = `
+        DELETE FROM timelines
+        WHERE user_id = $1 AND post_id = ANY($2)
+    `
+
+var getPostIDsWithoutAuthor = `
+        SELECT DISTINCT post_id
+        FROM timelines
+        WHERE author_id IS NULL AND post_id > $1
+        ORDER BY post_id
+        LIMIT $2
+    `
+
+var setPostAuthor = `
+        UPDATE timelines
+        SET author_id = $2
+        WHERE post_id = $1 AND author_id IS NULL
+    `
+
 var _ timeline.TimelineRepository = (*TimelineRepository)(nil)
 
 type TimelineRepository struct {
@@ -27,8 +62,13 @@ type TimelineRepository struct {
 }
 
 func (t *TimelineRepository) RemovePostFromTimeline(ctx context.Context, userID string, timelinePost timeline.PostTimeline) error {
-	//TODO implement me
-	panic("implement me")
+	_, err := t.db.ExecContext(ctx, removePostTimelineRow, userID, timelinePost.PostID)
+	if err != nil {
+		log.Err(err).Msg("error removing post from user timeline postgres")
+		return err
+	}
+
+	return nil
 }
 
 func NewTimelineRepository(db *sqlx.DB) *TimelineRepository {
@@ -55,9 +95,15 @@ func (t *TimelineRepository) GetUserPostTimeline(ctx context.Context, userID str
 func (t *TimelineRepository) GetUserTimeline(ctx context.Context, userID string, filter timeline.TimelineFilter) (*timeline.UserTimeline, error) {
 	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
 
-	sb.Select("post_id", "published_at")
+	sb.Select("post_id", "author_id", "published_at")
 	sb.From("timelines")
 	sb.Where(sb.Equal("user_id", userID))
+	if !filter.DateFrom.IsZero() {
+		sb.Where(sb.GreaterEqualThan("published_at", filter.DateFrom))
+	}
+	if !filter.DateTo.IsZero() {
+		sb.Where(sb.LessEqualThan("published_at", filter.DateTo))
+	}
 	sb.OrderBy("published_at DESC")
 	query, args := sb.Build()
 
@@ -89,11 +135,12 @@ func (t *TimelineRepository) GetUserTimeline(ctx context.Context, userID string,
 func (t *TimelineRepository) AddPostToUserTimeline(ctx context.Context, userID string, timelinePost timeline.PostTimeline) error {
 	_, err := t.db.NamedExecContext(ctx,
 		`
-		INSERT INTO timelines (user_id, post_id, published_at,created_at)
-		VALUES (:user_id, :post_id, :published_at,:created_at)`,
+		INSERT INTO timelines (user_id, post_id, author_id, published_at,created_at)
+		VALUES (:user_id, :post_id, :author_id, :published_at,:created_at)`,
 		map[string]interface{}{
 			"user_id":      userID,
 			"post_id":      timelinePost.PostID,
+			"author_id":    timelinePost.AuthorID,
 			"published_at": timelinePost.PublishedAt,
 			"created_at":   time.Now(),
 		})
@@ -106,14 +153,105 @@ func (t *TimelineRepository) AddPostToUserTimeline(ctx context.Context, userID s
 	return nil
 }
 
+func (t *TimelineRepository) GetAuthorPostsFromTimeline(ctx context.Context, userID string, authorID string) ([]timeline.PostTimeline, error) {
+	var pgPostTimelineRows []postTimelineRow
+	err := t.db.SelectContext(ctx, &pgPostTimelineRows, getAuthorPostsRows, userID, authorID)
+	if err != nil {
+		log.Err(err).Msg("error getting author posts from user timeline postgres")
+		return nil, fmt.Errorf("error getting author posts: %w", err)
+	}
+
+	postTimelineRows := make([]timeline.PostTimeline, len(pgPostTimelineRows))
+	for i, pgPostTimelineRow := range pgPostTimelineRows {
+		postTimelineRows[i] = pgPostTimelineRow.toDomain()
+	}
+
+	return postTimelineRows, nil
+}
+
+func (t *TimelineRepository) RemovePostsFromTimeline(ctx context.Context, userID string, postIDs []string) error {
+	_, err := t.db.ExecContext(ctx, removePostsRows, userID, pq.Array(postIDs))
+	if err != nil {
+		log.Err(err).Msg("error removing posts from user timeline postgres")
+		return fmt.Errorf("error removing posts: %w", err)
+	}
+
+	return nil
+}
+
+func (t *TimelineRepository) GetPostIDsWithoutAuthor(ctx context.Context, afterPostID string, limit int) ([]string, error) {
+	var postIDs []string
+	err := t.db.SelectContext(ctx, &postIDs, getPostIDsWithoutAuthor, afterPostID, limit)
+	if err != nil {
+		log.Err(err).Msg("error getting posts without author from postgres")
+		return nil, fmt.Errorf("error getting posts without author: %w", err)
+	}
+
+	return postIDs, nil
+}
+
+func (t *TimelineRepository) SetPostAuthor(ctx context.Context, postID string, authorID string) error {
+	_, err := t.db.ExecContext(ctx, setPostAuthor, postID, authorID)
+	if err != nil {
+		log.Err(err).Msg("error setting post author postgres")
+		return fmt.Errorf("error setting post author: %w", err)
+	}
+
+	return nil
+}
+
+// GetNewPosts relies on the (user_id, published_at, post_id) index, the limit bounds the rows read.
+func (t *TimelineRepository) GetNewPosts(ctx context.Context, userID string, filter timeline.NewPostsFilter) ([]timeline.PostTimeline, error) {
+	sb := sqlbuilder.PostgreSQL.NewSelectBuilder()
+
+	sb.Select("post_id", "author_id", "published_at")
+	sb.From("timelines")
+	sb.Where(sb.Equal("user_id", userID))
+	if filter.SinceID != "" {
+		sb.Where(sb.Or(
+			sb.GreaterThan("published_at", filter.Since),
+			sb.And(sb.Equal("published_at", filter.Since), sb.GreaterThan("post_id", filter.SinceID)),
+		))
+	} else {
+		sb.Where(sb.GreaterThan("published_at", filter.Since))
+	}
+	if len(filter.ExcludedAuthorIDs) > 0 {
+		sb.Where(sb.Or(
+			sb.IsNull("author_id"),
+			sb.NotIn("author_id", sqlbuilder.Flatten(filter.ExcludedAuthorIDs)...),
+		))
+	}
+	sb.OrderBy("published_at DESC", "post_id DESC")
+	if filter.Limit > 0 {
+		sb.Limit(filter.Limit)
+	}
+	query, args := sb.Build()
+
+	var pgPostTimelineRows []postTimelineRow
+	err := t.db.SelectContext(ctx, &pgPostTimelineRows, query, args...)
+	if err != nil {
+		log.Err(err).Msg("error getting new posts from postgres")
+		return nil, fmt.Errorf("error getting new posts: %w", err)
+	}
+
+	postTimelineRows := make([]timeline.PostTimeline, len(pgPostTimelineRows))
+	for i, pgPostTimelineRow := range pgPostTimelineRows {
+		postTimelineRows[i] = pgPostTimelineRow.toDomain()
+	}
+
+	return postTimelineRows, nil
+}
+
 type postTimelineRow struct {
-	PostID      string    `db:"post_id"`
-	PublishedAt time.Time `db:"published_at"`
+	PostID      string         `db:"post_id"`
+	AuthorID    sql.NullString `db:"author_id"`
+	PublishedAt time.Time      `db:"published_at"`
 }
 
 func (p *postTimelineRow) toDomain() timeline.PostTimeline {
 	return timeline.PostTimeline{
 		PostID:      p.PostID,
+		AuthorID:    p.AuthorID.String,
 		PublishedAt: p.PublishedAt,
 	}
 }
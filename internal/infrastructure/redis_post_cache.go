@@ -0,0 +1,148 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+	"uala-timeline-service/internal/domain/posts"
+)
+
+var _ PostCacheStore = (*RedisPostCache)(nil)
+
+const postCacheKeyPrefix = "timeline:post:"
+
+// RedisPostCache shares the cached posts between the replicas, keys expire after the ttl.
+type RedisPostCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewRedisPostCache(client *redis.Client, ttl time.Duration) *RedisPostCache {
+	return &RedisPostCache{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+func (c *RedisPostCache) GetPosts(ctx context.Context, postIDs []string) (map[string]posts.Post, error) {
+	if len(postIDs) == 0 {
+		return map[string]posts.Post{}, nil
+	}
+
+	keys := make([]string, len(postIDs))
+	for i, id := range postIDs {
+		keys[i] = postCacheKey(id)
+	}
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error getting posts from redis: %w", err)
+	}
+
+	found := make(map[string]posts.Post, len(postIDs))
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var post redisPost
+		if err := json.Unmarshal([]byte(data), &post); err != nil {
+			return nil, fmt.Errorf("error unmarshaling post data: %w", err)
+		}
+		found[post.ID] = post.toDomain()
+	}
+	return found, nil
+}
+
+func (c *RedisPostCache) SetPosts(ctx context.Context, posts []posts.Post) error {
+	pipe := c.client.Pipeline()
+	for _, post := range posts {
+		data, err := json.Marshal(newRedisPost(post))
+		if err != nil {
+			return fmt.Errorf("error marshaling post data: %w", err)
+		}
+		pipe.Set(ctx, postCacheKey(post.ID), data, c.ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("error setting posts in redis: %w", err)
+	}
+	return nil
+}
+
+func (c *RedisPostCache) DeletePosts(ctx context.Context, postIDs []string) error {
+	keys := make([]string, len(postIDs))
+	for i, id := range postIDs {
+		keys[i] = postCacheKey(id)
+	}
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("error deleting posts from redis: %w", err)
+	}
+	return nil
+}
+
+func postCacheKey(postID string) string {
+	return postCacheKeyPrefix + postID
+}
+
+type redisPost struct {
+	ID          string         `json:"id"`
+	Contents    []redisContent `json:"contents"`
+	AuthorID    string         `json:"author_id"`
+	PublishedAt time.Time      `json:"published_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+type redisContent struct {
+	Type            string             `json:"type"`
+	Text            *string            `json:"text,omitempty"`
+	Url             *string            `json:"url,omitempty"`
+	Media           *posts.Media       `json:"media,omitempty"`
+	Preview         *posts.LinkPreview `json:"preview,omitempty"`
+	MentionedUserID *string            `json:"mentioned_user_id,omitempty"`
+	Data            json.RawMessage    `json:"data,omitempty"`
+}
+
+func newRedisPost(post posts.Post) redisPost {
+	contents := make([]redisContent, len(post.Contents))
+	for i, content := range post.Contents {
+		contents[i] = redisContent{
+			Type:            content.Type,
+			Text:            content.Text,
+			Url:             content.Url,
+			Media:           content.Media,
+			Preview:         content.Preview,
+			MentionedUserID: content.MentionedUserID,
+			Data:            content.Data,
+		}
+	}
+	return redisPost{
+		ID:          post.ID,
+		Contents:    contents,
+		AuthorID:    post.AuthorID,
+		PublishedAt: post.PublishedAt,
+		UpdatedAt:   post.UpdatedAt,
+	}
+}
+
+func (p redisPost) toDomain() posts.Post {
+	contents := make([]posts.Content, len(p.Contents))
+	for i, content := range p.Contents {
+		contents[i] = posts.Content{
+			Type:            content.Type,
+			Text:            content.Text,
+			Url:             content.Url,
+			Media:           content.Media,
+			Preview:         content.Preview,
+			MentionedUserID: content.MentionedUserID,
+			Data:            content.Data,
+		}
+	}
+	return posts.Post{
+		ID:          p.ID,
+		Contents:    contents,
+		AuthorID:    p.AuthorID,
+		PublishedAt: p.PublishedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
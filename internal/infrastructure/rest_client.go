@@ -0,0 +1,89 @@
+package infrastructure
+
+import (
+	"context"
+	"github.com/go-resty/resty/v2"
+	"math/rand/v2"
+	"net/http"
+	"time"
+	"uala-timeline-service/libs/circuitbreaker"
+)
+
+type RestClientConfig struct {
+	// Timeout bounds each attempt, retries included
+	Timeout time.Duration
+	// Retries is the number of attempts after the first one
+	Retries      int
+	RetryWait    time.Duration
+	RetryMaxWait time.Duration
+	// BreakerFailures consecutive failures open the breaker for BreakerOpenTimeout
+	BreakerFailures    int
+	BreakerOpenTimeout time.Duration
+}
+
+// RestClient runs the GETs to an upstream with a timeout, retries connection errors and
+// server errors with jittered exponential backoff and fails fast with
+// circuitbreaker.ErrOpen while the upstream is unhealthy. Repositories of the same
+// upstream share the client so they share the breaker.
+type RestClient struct {
+	client  *resty.Client
+	breaker *circuitbreaker.Breaker
+	config  RestClientConfig
+}
+
+func NewRestClient(config RestClientConfig) *RestClient {
+	return &RestClient{
+		client:  resty.New().SetTimeout(config.Timeout),
+		breaker: circuitbreaker.New(config.BreakerFailures, config.BreakerOpenTimeout),
+		config:  config,
+	}
+}
+
+// Get returns the last response and error, the caller decides what a client error means.
+// Only GETs are retried because they are idempotent.
+func (c *RestClient) Get(ctx context.Context, endpoint string, queryParams map[string]string) (*resty.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.breaker.Allow(); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.client.R().
+			SetContext(ctx).
+			SetQueryParams(queryParams).
+			Get(endpoint)
+
+		failed := err != nil || resp.StatusCode() >= http.StatusInternalServerError
+		switch {
+		case ctx.Err() != nil:
+			c.breaker.Cancel()
+			return resp, err
+		case failed:
+			c.breaker.Failure()
+		default:
+			c.breaker.Success()
+			return resp, err
+		}
+
+		if attempt >= c.config.Retries {
+			return resp, err
+		}
+		select {
+		case <-ctx.Done():
+			return resp, err
+		case <-time.After(c.backoff(attempt)):
+		}
+	}
+}
+
+// backoff doubles the wait on every attempt up to RetryMaxWait, half of it is random so
+// the replicas do not retry in sync.
+func (c *RestClient) backoff(attempt int) time.Duration {
+	wait := c.config.RetryWait << attempt
+	if c.config.RetryMaxWait > 0 && (wait > c.config.RetryMaxWait || wait <= 0) {
+		wait = c.config.RetryMaxWait
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return wait/2 + rand.N(wait/2+1)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/go-resty/resty/v2"
 	"github.com/rs/zerolog/log"
 	"uala-timeline-service/internal/domain/follows"
 )
@@ -12,24 +11,21 @@ import (
 var _ follows.FollowRepository = (*RestFollowsRepository)(nil)
 
 type RestFollowsRepository struct {
-	client  *resty.Client
+	client  *RestClient
 	baseURL string
 }
 
 func (r *RestFollowsRepository) GetUserFollowerIDs(ctx context.Context, userID string) ([]string, error) {
 	endpoint := fmt.Sprintf("%s/api/v1/follow/user/%s/followers", r.baseURL, userID)
-	resp, err := r.client.R().
-		SetContext(ctx).
-		Get(endpoint)
-
+	resp, err := r.client.Get(ctx, endpoint, nil)
 	if err != nil {
 		log.Err(err).Msg("error getting user followers")
-		return nil, fmt.Errorf("error fetching post: %w", err)
+		return nil, fmt.Errorf("%w: error fetching followers: %w", follows.ErrUpstreamUnavailable, err)
 	}
 
 	if resp.IsError() {
 		log.Err(err).Msg("error getting user followers")
-		return nil, fmt.Errorf("API returned error status: %d - %s", resp.StatusCode(), resp.String())
+		return nil, statusError(resp, follows.ErrUpstreamUnavailable)
 	}
 
 	var response followersResponse
@@ -41,9 +37,9 @@ func (r *RestFollowsRepository) GetUserFollowerIDs(ctx context.Context, userID s
 	return response.toDomain(), nil
 }
 
-func NewRestFollowsRepository(baseURL string) *RestFollowsRepository {
+func NewRestFollowsRepository(baseURL string, client *RestClient) *RestFollowsRepository {
 	return &RestFollowsRepository{
-		client:  resty.New(),
+		client:  client,
 		baseURL: baseURL,
 	}
 }
@@ -6,40 +6,61 @@ import (
 	"fmt"
 	"github.com/go-resty/resty/v2"
 	"github.com/rs/zerolog/log"
+	"net/http"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 	"uala-timeline-service/internal/domain/posts"
 )
 
 var _ posts.PostRepository = (*RestPostRepository)(nil)
 
+const (
+	defaultMGetChunkSize   = 50
+	defaultMGetConcurrency = 4
+)
+
 type RestPostRepository struct {
-	client  *resty.Client
-	baseURL string
+	client      *RestClient
+	baseURL     string
+	chunkSize   int
+	concurrency int
 }
 
-func NewRestPostRepository(baseURL string) *RestPostRepository {
+// NewRestPostRepository fetches the posts of MGetPosts in chunks of chunkSize IDs, at most
+// concurrency chunks at a time.
+func NewRestPostRepository(baseURL string, client *RestClient, chunkSize int, concurrency int) *RestPostRepository {
+	if chunkSize <= 0 {
+		chunkSize = defaultMGetChunkSize
+	}
+	if concurrency <= 0 {
+		concurrency = defaultMGetConcurrency
+	}
 	return &RestPostRepository{
-		client:  resty.New(),
-		baseURL: baseURL,
+		client:      client,
+		baseURL:     baseURL,
+		chunkSize:   chunkSize,
+		concurrency: concurrency,
 	}
 }
 
 func (r *RestPostRepository) GetPostById(ctx context.Context, id string) (*posts.Post, error) {
 	endpoint := fmt.Sprintf("%s/api/v1/posts/%s", r.baseURL, id)
 
-	resp, err := r.client.R().
-		SetContext(ctx).
-		Get(endpoint)
-
+	resp, err := r.client.Get(ctx, endpoint, nil)
 	if err != nil {
 		log.Err(err).Msg("error getting post")
-		return nil, fmt.Errorf("error fetching post: %w", err)
+		return nil, fmt.Errorf("%w: error fetching post: %w", posts.ErrUpstreamUnavailable, err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", posts.ErrPostNotFound, id)
 	}
 
 	if resp.IsError() {
 		log.Err(err).Msg("error getting post")
-		return nil, fmt.Errorf("API returned error status: %d - %s", resp.StatusCode(), resp.String())
+		return nil, statusError(resp, posts.ErrUpstreamUnavailable)
 	}
 
 	var response postResponse
@@ -55,25 +76,80 @@ type multiGetResponse struct {
 	Posts []postResponse `json:"posts"`
 }
 
-func (r *RestPostRepository) MGetPosts(ctx context.Context, postIDs []string) ([]posts.Post, error) {
+// MGetPosts splits the IDs in chunks fetched in parallel. A chunk that fails does not fail
+// the call, its IDs are reported as unavailable; the call only fails when every chunk does.
+func (r *RestPostRepository) MGetPosts(ctx context.Context, postIDs []string) (*posts.MGetPostsResult, error) {
 	if len(postIDs) == 0 {
-		return []posts.Post{}, nil
+		return &posts.MGetPostsResult{Posts: []posts.Post{}}, nil
+	}
+
+	var chunks [][]string
+	for chunk := range slices.Chunk(postIDs, r.chunkSize) {
+		chunks = append(chunks, chunk)
+	}
+
+	chunkPosts := make([][]posts.Post, len(chunks))
+	chunkErrs := make([]error, len(chunks))
+	semaphore := make(chan struct{}, r.concurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			chunkPosts[i], chunkErrs[i] = r.mGetChunk(ctx, chunk)
+		}()
 	}
-	idsParam := strings.Join(postIDs, ",")
-	endpoint := fmt.Sprintf("%s/api/v1/posts?ids=%s", r.baseURL, idsParam)
+	wg.Wait()
 
-	resp, err := r.client.R().
-		SetContext(ctx).
-		Get(endpoint)
+	found := make(map[string]posts.Post, len(postIDs))
+	unavailable := make(map[string]struct{})
+	failedChunks := 0
+	for i, chunk := range chunks {
+		if chunkErrs[i] != nil {
+			failedChunks++
+			for _, id := range chunk {
+				unavailable[id] = struct{}{}
+			}
+			continue
+		}
+		for _, post := range chunkPosts[i] {
+			found[post.ID] = post
+		}
+	}
+	if failedChunks == len(chunks) {
+		return nil, chunkErrs[0]
+	}
 
+	result := &posts.MGetPostsResult{Posts: make([]posts.Post, 0, len(found))}
+	for _, id := range postIDs {
+		if post, ok := found[id]; ok {
+			result.Posts = append(result.Posts, post)
+		} else if _, ok := unavailable[id]; ok {
+			result.UnavailableIDs = append(result.UnavailableIDs, id)
+		} else {
+			result.NotFoundIDs = append(result.NotFoundIDs, id)
+		}
+	}
+
+	return result, nil
+}
+
+func (r *RestPostRepository) mGetChunk(ctx context.Context, postIDs []string) ([]posts.Post, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/posts", r.baseURL)
+
+	resp, err := r.client.Get(ctx, endpoint, map[string]string{
+		"ids": strings.Join(postIDs, ","),
+	})
 	if err != nil {
 		log.Err(err).Msg("error getting mpost")
-		return nil, fmt.Errorf("error fetching posts: %w", err)
+		return nil, fmt.Errorf("%w: error fetching posts: %w", posts.ErrUpstreamUnavailable, err)
 	}
 
 	if resp.IsError() {
 		log.Err(err).Msg("error getting mpost")
-		return nil, fmt.Errorf("API returned error status: %d - %s", resp.StatusCode(), resp.String())
+		return nil, statusError(resp, posts.ErrUpstreamUnavailable)
 	}
 
 	var response multiGetResponse
@@ -90,6 +166,45 @@ func (r *RestPostRepository) MGetPosts(ctx context.Context, postIDs []string) ([
 	return posts, nil
 }
 
+func (r *RestPostRepository) GetAuthorPosts(ctx context.Context, authorID string, since time.Time) ([]posts.Post, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/posts/user/%s", r.baseURL, authorID)
+
+	resp, err := r.client.Get(ctx, endpoint, map[string]string{
+		"from": since.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		log.Err(err).Msg("error getting author posts")
+		return nil, fmt.Errorf("%w: error fetching posts: %w", posts.ErrUpstreamUnavailable, err)
+	}
+
+	if resp.IsError() {
+		log.Err(err).Msg("error getting author posts")
+		return nil, statusError(resp, posts.ErrUpstreamUnavailable)
+	}
+
+	var response multiGetResponse
+	err = json.Unmarshal(resp.Body(), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	posts := make([]posts.Post, len(response.Posts))
+	for i, apiPost := range response.Posts {
+		posts[i] = *apiPost.toDomain()
+	}
+
+	return posts, nil
+}
+
+// statusError flags server errors with the upstream unavailable error of the domain, client
+// errors are returned as they are.
+func statusError(resp *resty.Response, errUpstreamUnavailable error) error {
+	if resp.StatusCode() >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: API returned error status: %d - %s", errUpstreamUnavailable, resp.StatusCode(), resp.String())
+	}
+	return fmt.Errorf("API returned error status: %d - %s", resp.StatusCode(), resp.String())
+}
+
 type postResponse struct {
 	ID          string        `json:"id"`
 	Contents    []PostContent `json:"contents"`
@@ -99,17 +214,78 @@ type postResponse struct {
 }
 
 type PostContent struct {
-	Type string  `json:"type"`
-	Text *string `json:"text,omitempty"`
+	Type            string           `json:"type"`
+	Text            *string          `json:"text,omitempty"`
+	Url             *string          `json:"url,omitempty"`
+	Media           *PostMedia       `json:"media,omitempty"`
+	Preview         *PostLinkPreview `json:"preview,omitempty"`
+	MentionedUserID *string          `json:"user_id,omitempty"`
+	// raw keeps the contents of types unknown to the service
+	raw json.RawMessage
+}
+
+func (c *PostContent) UnmarshalJSON(data []byte) error {
+	type postContent PostContent
+	var content postContent
+	if err := json.Unmarshal(data, &content); err != nil {
+		return err
+	}
+	*c = PostContent(content)
+	c.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+type PostMedia struct {
+	MimeType     string  `json:"mime_type"`
+	Width        int     `json:"width"`
+	Height       int     `json:"height"`
+	DurationMs   int     `json:"duration_ms"`
+	ThumbnailUrl *string `json:"thumbnail_url,omitempty"`
+	AltText      *string `json:"alt_text,omitempty"`
+}
+
+type PostLinkPreview struct {
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+	ImageUrl    *string `json:"image_url,omitempty"`
+	SiteName    *string `json:"site_name,omitempty"`
+}
+
+func (c PostContent) toDomain() posts.Content {
+	content := posts.Content{
+		Type:            c.Type,
+		Text:            c.Text,
+		Url:             c.Url,
+		MentionedUserID: c.MentionedUserID,
+	}
+	if !posts.IsKnownContentType(c.Type) {
+		content.Data = c.raw
+	}
+	if c.Media != nil {
+		content.Media = &posts.Media{
+			MimeType:     c.Media.MimeType,
+			Width:        c.Media.Width,
+			Height:       c.Media.Height,
+			DurationMs:   c.Media.DurationMs,
+			ThumbnailUrl: c.Media.ThumbnailUrl,
+			AltText:      c.Media.AltText,
+		}
+	}
+	if c.Preview != nil {
+		content.Preview = &posts.LinkPreview{
+			Title:       c.Preview.Title,
+			Description: c.Preview.Description,
+			ImageUrl:    c.Preview.ImageUrl,
+			SiteName:    c.Preview.SiteName,
+		}
+	}
+	return content
 }
 
 func (p postResponse) toDomain() *posts.Post {
 	contents := make([]posts.Content, len(p.Contents))
 	for i, content := range p.Contents {
-		contents[i] = posts.Content{
-			Type: content.Type,
-			Text: content.Text,
-		}
+		contents[i] = content.toDomain()
 	}
 	return &posts.Post{
 		ID:          p.ID,
@@ -0,0 +1,232 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"uala-timeline-service/internal/domain/posts"
+	"uala-timeline-service/libs/circuitbreaker"
+)
+
+func TestRestPostRepository_GetPostById(t *testing.T) {
+	const postBody = `{"id": "post-123", "author_id": "author-789", "contents": [{"type": "text", "text": "hello"}]}`
+
+	tests := []struct {
+		name             string
+		statuses         []int
+		calls            int
+		expectedError    error
+		expectedAttempts int32
+		expectedState    circuitbreaker.State
+	}{
+		{
+			name:             "should retry server errors",
+			statuses:         []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			calls:            1,
+			expectedAttempts: 3,
+			expectedState:    circuitbreaker.Closed,
+		},
+		{
+			name:             "should return post not found without retrying",
+			statuses:         []int{http.StatusNotFound},
+			calls:            1,
+			expectedError:    posts.ErrPostNotFound,
+			expectedAttempts: 1,
+			expectedState:    circuitbreaker.Closed,
+		},
+		{
+			name:             "should return upstream unavailable when retries run out",
+			statuses:         []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			calls:            1,
+			expectedError:    posts.ErrUpstreamUnavailable,
+			expectedAttempts: 3,
+			expectedState:    circuitbreaker.Closed,
+		},
+		{
+			name:             "should fail fast once the breaker opens",
+			statuses:         []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			calls:            3,
+			expectedError:    posts.ErrUpstreamUnavailable,
+			expectedAttempts: 5,
+			expectedState:    circuitbreaker.Open,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := attempts.Add(1)
+				status := tt.statuses[min(int(attempt), len(tt.statuses))-1]
+				w.WriteHeader(status)
+				if status == http.StatusOK {
+					w.Write([]byte(postBody))
+				}
+			}))
+			defer server.Close()
+
+			client := NewRestClient(RestClientConfig{
+				Timeout:            time.Second,
+				Retries:            2,
+				RetryWait:          time.Millisecond,
+				RetryMaxWait:       5 * time.Millisecond,
+				BreakerFailures:    5,
+				BreakerOpenTimeout: time.Minute,
+			})
+			repository := NewRestPostRepository(server.URL, client, 0, 0)
+
+			// Act
+			var post *posts.Post
+			var err error
+			for range tt.calls {
+				post, err = repository.GetPostById(context.Background(), "post-123")
+			}
+
+			// Assert
+			assert.Equal(t, tt.expectedAttempts, attempts.Load())
+			assert.Equal(t, tt.expectedState, client.breaker.State())
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "post-123", post.ID)
+		})
+	}
+}
+
+func TestRestPostRepository_MGetPosts(t *testing.T) {
+	tests := []struct {
+		name                   string
+		postIDs                []string
+		existing               map[string]bool
+		failingIDs             map[string]bool
+		expectedError          error
+		expectedPostIDs        []string
+		expectedNotFoundIDs    []string
+		expectedUnavailableIDs []string
+		expectedRequests       int32
+	}{
+		{
+			name:             "should fetch chunks and keep the order of the ids",
+			postIDs:          []string{"post-5", "post-4", "post-3", "post-2", "post-1"},
+			existing:         map[string]bool{"post-1": true, "post-2": true, "post-3": true, "post-4": true, "post-5": true},
+			expectedPostIDs:  []string{"post-5", "post-4", "post-3", "post-2", "post-1"},
+			expectedRequests: 3,
+		},
+		{
+			name:                "should report missing posts as not found",
+			postIDs:             []string{"post-1", "post-2", "post-3"},
+			existing:            map[string]bool{"post-1": true, "post-3": true},
+			expectedPostIDs:     []string{"post-1", "post-3"},
+			expectedNotFoundIDs: []string{"post-2"},
+			expectedRequests:    2,
+		},
+		{
+			name:                   "should report the posts of failing chunks as unavailable",
+			postIDs:                []string{"post-1", "post-2", "post-3", "post-4", "post-5"},
+			existing:               map[string]bool{"post-1": true, "post-2": true, "post-3": true, "post-4": true, "post-5": true},
+			failingIDs:             map[string]bool{"post-3": true},
+			expectedPostIDs:        []string{"post-1", "post-2", "post-5"},
+			expectedUnavailableIDs: []string{"post-3", "post-4"},
+			expectedRequests:       3,
+		},
+		{
+			name:             "should fail when every chunk fails",
+			postIDs:          []string{"post-1", "post-2", "post-3"},
+			failingIDs:       map[string]bool{"post-1": true, "post-3": true},
+			expectedError:    posts.ErrUpstreamUnavailable,
+			expectedRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				var found []postResponse
+				for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+					if tt.failingIDs[id] {
+						w.WriteHeader(http.StatusServiceUnavailable)
+						return
+					}
+					if tt.existing[id] {
+						found = append(found, postResponse{ID: id})
+					}
+				}
+				json.NewEncoder(w).Encode(multiGetResponse{Posts: found})
+			}))
+			defer server.Close()
+
+			client := NewRestClient(RestClientConfig{
+				Timeout:            time.Second,
+				BreakerFailures:    5,
+				BreakerOpenTimeout: time.Minute,
+			})
+			repository := NewRestPostRepository(server.URL, client, 2, 2)
+
+			// Act
+			result, err := repository.MGetPosts(context.Background(), tt.postIDs)
+
+			// Assert
+			assert.Equal(t, tt.expectedRequests, requests.Load())
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			var postIDs []string
+			for _, post := range result.Posts {
+				postIDs = append(postIDs, post.ID)
+			}
+			assert.Equal(t, tt.expectedPostIDs, postIDs)
+			assert.Equal(t, tt.expectedNotFoundIDs, result.NotFoundIDs)
+			assert.Equal(t, tt.expectedUnavailableIDs, result.UnavailableIDs)
+		})
+	}
+}
+
+func TestRestPostRepository_GetPostByIdContents(t *testing.T) {
+	// Setup
+	const postBody = `{"id": "post-123", "author_id": "author-789", "contents": [
+		{"type": "text", "text": "hello"},
+		{"type": "image", "url": "https://cdn/img.png", "media": {"mime_type": "image/png", "width": 640, "height": 480, "alt_text": "a cat"}},
+		{"type": "video", "url": "https://cdn/clip.mp4", "media": {"mime_type": "video/mp4", "width": 1280, "height": 720, "duration_ms": 15000, "thumbnail_url": "https://cdn/clip.png"}},
+		{"type": "link", "url": "https://blog/post", "preview": {"title": "A post", "site_name": "blog"}},
+		{"type": "mention", "text": "@ana", "user_id": "user-42"},
+		{"type": "hashtag", "text": "golang"},
+		{"type": "poll", "options": ["yes", "no"]}
+	]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(postBody))
+	}))
+	defer server.Close()
+	repository := NewRestPostRepository(server.URL, NewRestClient(RestClientConfig{Timeout: time.Second}), 0, 0)
+
+	// Act
+	post, err := repository.GetPostById(context.Background(), "post-123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, post.Contents, 7)
+	assert.Equal(t, "hello", *post.Contents[0].Text)
+	assert.Equal(t, &posts.Media{MimeType: "image/png", Width: 640, Height: 480, AltText: stringPtr("a cat")}, post.Contents[1].Media)
+	assert.Equal(t, "https://cdn/img.png", *post.Contents[1].Url)
+	assert.Equal(t, 15000, post.Contents[2].Media.DurationMs)
+	assert.Equal(t, "https://cdn/clip.png", *post.Contents[2].Media.ThumbnailUrl)
+	assert.Equal(t, &posts.LinkPreview{Title: stringPtr("A post"), SiteName: stringPtr("blog")}, post.Contents[3].Preview)
+	assert.Equal(t, "user-42", *post.Contents[4].MentionedUserID)
+	assert.Equal(t, "golang", *post.Contents[5].Text)
+	assert.Nil(t, post.Contents[5].Data)
+	assert.Equal(t, "poll", post.Contents[6].Type)
+	assert.JSONEq(t, `{"type": "poll", "options": ["yes", "no"]}`, string(post.Contents[6].Data))
+}
@@ -0,0 +1,62 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"uala-timeline-service/internal/domain/relationships"
+)
+
+var _ relationships.RelationshipRepository = (*RestRelationshipRepository)(nil)
+
+type RestRelationshipRepository struct {
+	client  *RestClient
+	baseURL string
+}
+
+func NewRestRelationshipRepository(baseURL string, client *RestClient) *RestRelationshipRepository {
+	return &RestRelationshipRepository{
+		client:  client,
+		baseURL: baseURL,
+	}
+}
+
+func (r *RestRelationshipRepository) GetBlockedAuthorIDs(ctx context.Context, userID string) ([]string, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/follow/user/%s/blocked", r.baseURL, userID)
+	return r.getUserIDs(ctx, endpoint)
+}
+
+func (r *RestRelationshipRepository) GetMutedAuthorIDs(ctx context.Context, userID string) ([]string, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/follow/user/%s/muted", r.baseURL, userID)
+	return r.getUserIDs(ctx, endpoint)
+}
+
+func (r *RestRelationshipRepository) getUserIDs(ctx context.Context, endpoint string) ([]string, error) {
+	resp, err := r.client.Get(ctx, endpoint, nil)
+	if err != nil {
+		log.Err(err).Msg("error getting user relationships")
+		return nil, fmt.Errorf("%w: error fetching relationships: %w", relationships.ErrUpstreamUnavailable, err)
+	}
+
+	if resp.IsError() {
+		log.Err(err).Msg("error getting user relationships")
+		return nil, statusError(resp, relationships.ErrUpstreamUnavailable)
+	}
+
+	var response relationshipsResponse
+	err = json.Unmarshal(resp.Body(), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.toDomain(), nil
+}
+
+type relationshipsResponse struct {
+	UserIDs []string `json:"user_ids"`
+}
+
+func (p relationshipsResponse) toDomain() []string {
+	return p.UserIDs
+}
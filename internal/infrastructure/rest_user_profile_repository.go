@@ -0,0 +1,78 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"strings"
+	"uala-timeline-service/internal/domain/users"
+)
+
+var _ users.UserProfileRepository = (*RestUserProfileRepository)(nil)
+
+type RestUserProfileRepository struct {
+	client  *RestClient
+	baseURL string
+}
+
+func NewRestUserProfileRepository(baseURL string, client *RestClient) *RestUserProfileRepository {
+	return &RestUserProfileRepository{
+		client:  client,
+		baseURL: baseURL,
+	}
+}
+
+func (r *RestUserProfileRepository) MGetUserProfiles(ctx context.Context, userIDs []string) (map[string]users.UserProfile, error) {
+	if len(userIDs) == 0 {
+		return map[string]users.UserProfile{}, nil
+	}
+
+	endpoint := fmt.Sprintf("%s/api/v1/users", r.baseURL)
+	resp, err := r.client.Get(ctx, endpoint, map[string]string{
+		"ids": strings.Join(userIDs, ","),
+	})
+	if err != nil {
+		log.Err(err).Msg("error getting user profiles")
+		return nil, fmt.Errorf("%w: error fetching user profiles: %w", users.ErrUpstreamUnavailable, err)
+	}
+
+	if resp.IsError() {
+		log.Err(err).Msg("error getting user profiles")
+		return nil, statusError(resp, users.ErrUpstreamUnavailable)
+	}
+
+	var response userProfilesResponse
+	err = json.Unmarshal(resp.Body(), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.toDomain(), nil
+}
+
+type userProfilesResponse struct {
+	Users []userProfileResponse `json:"users"`
+}
+
+type userProfileResponse struct {
+	ID          string  `json:"id"`
+	Username    string  `json:"username"`
+	DisplayName string  `json:"display_name"`
+	AvatarUrl   *string `json:"avatar_url,omitempty"`
+	Verified    bool    `json:"verified"`
+}
+
+func (p userProfilesResponse) toDomain() map[string]users.UserProfile {
+	profiles := make(map[string]users.UserProfile, len(p.Users))
+	for _, user := range p.Users {
+		profiles[user.ID] = users.UserProfile{
+			UserID:      user.ID,
+			Username:    user.Username,
+			DisplayName: user.DisplayName,
+			AvatarUrl:   user.AvatarUrl,
+			Verified:    user.Verified,
+		}
+	}
+	return profiles
+}
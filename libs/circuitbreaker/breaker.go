@@ -0,0 +1,109 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	Closed State = iota
+	Open
+	HalfOpen
+)
+
+// Breaker opens after FailureThreshold consecutive failures and fails fast until
+// OpenTimeout has passed. Then a single probe goes through, its result closes the breaker
+// or opens it again. A threshold of zero disables it.
+type Breaker struct {
+	mu               sync.Mutex
+	failureThreshold int
+	openTimeout      time.Duration
+	state            State
+	failures         int
+	openedAt         time.Time
+	probing          bool
+	now              func() time.Time
+}
+
+func New(failureThreshold int, openTimeout time.Duration) *Breaker {
+	return &Breaker{
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+		now:              time.Now,
+	}
+}
+
+// Allow returns ErrOpen when the call must not reach the upstream. Every allowed call must
+// report its result with Success, Failure or Cancel.
+func (b *Breaker) Allow() error {
+	if b.failureThreshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case Open:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			return ErrOpen
+		}
+		b.state = HalfOpen
+		b.probing = true
+		return nil
+	case HalfOpen:
+		if b.probing {
+			return ErrOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = Closed
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *Breaker) Failure() {
+	if b.failureThreshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.state == HalfOpen || b.failures >= b.failureThreshold {
+		b.state = Open
+		b.openedAt = b.now()
+	}
+}
+
+// Cancel gives back an allowed call that ended without telling anything about the
+// upstream health, like a call canceled by the caller.
+func (b *Breaker) Cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
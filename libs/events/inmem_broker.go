@@ -0,0 +1,84 @@
+package events
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// InMemoryBroker delivers the published events to the subscribers of the same process,
+// topics accept the NATS * and > wildcards.
+type InMemoryBroker struct {
+	mu            sync.RWMutex
+	nextID        int
+	subscriptions map[int]inMemorySubscription
+}
+
+type inMemorySubscription struct {
+	topic   string
+	handler func(payload []byte)
+}
+
+func NewInMemoryBroker() *InMemoryBroker {
+	return &InMemoryBroker{
+		subscriptions: make(map[int]inMemorySubscription),
+	}
+}
+
+func (b *InMemoryBroker) Publish(ctx context.Context, event Publishable) error {
+	b.mu.RLock()
+	var handlers []func(payload []byte)
+	for _, subscription := range b.subscriptions {
+		if topicMatches(subscription.topic, event.Topic()) {
+			handlers = append(handlers, subscription.handler)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event.Payload())
+	}
+	return nil
+}
+
+func (b *InMemoryBroker) Subscribe(topic string, handler func(payload []byte)) (Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subscriptions[id] = inMemorySubscription{topic: topic, handler: handler}
+	return inMemoryUnsubscribe(func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscriptions, id)
+	}), nil
+}
+
+// Subscribers returns the number of open subscriptions.
+func (b *InMemoryBroker) Subscribers() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscriptions)
+}
+
+type inMemoryUnsubscribe func()
+
+func (u inMemoryUnsubscribe) Unsubscribe() error {
+	u()
+	return nil
+}
+
+func topicMatches(pattern string, topic string) bool {
+	patternTokens := strings.Split(pattern, ".")
+	topicTokens := strings.Split(topic, ".")
+	for i, token := range patternTokens {
+		if token == ">" {
+			return len(topicTokens) > i
+		}
+		if i >= len(topicTokens) || (token != "*" && token != topicTokens[i]) {
+			return false
+		}
+	}
+	return len(patternTokens) == len(topicTokens)
+}
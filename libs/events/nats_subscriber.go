@@ -0,0 +1,33 @@
+package events
+
+import (
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"log"
+)
+
+type NatsSubscriber struct {
+	conn *nats.Conn
+}
+
+func NewNatsSubscriber(host string) *NatsSubscriber {
+	nc, err := nats.Connect(fmt.Sprintf("nats://%s:4222", host))
+	if err != nil {
+		log.Fatal("Error conectando a NATS:", err)
+	}
+	return &NatsSubscriber{
+		conn: nc,
+	}
+}
+
+// Subscribe delivers every message of the topic to this process, it is not a queue
+// subscription so each replica receives its own copy.
+func (n *NatsSubscriber) Subscribe(topic string, handler func(payload []byte)) (Subscription, error) {
+	return n.conn.Subscribe(topic, func(msg *nats.Msg) {
+		handler(msg.Data)
+	})
+}
+
+func (n *NatsSubscriber) Close() {
+	n.conn.Close()
+}
@@ -0,0 +1,9 @@
+package events
+
+type Subscription interface {
+	Unsubscribe() error
+}
+
+type Subscriber interface {
+	Subscribe(topic string, handler func(payload []byte)) (Subscription, error)
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"container/list"
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// maxInMemoryBuckets is the number of buckets kept, the least recently used one is evicted
+// above it. It is the bucket most likely to be full again, and a new bucket starts full.
+const maxInMemoryBuckets = 10000
+
+// InMemoryLimiter keeps the buckets of the process, every replica enforces its own limit.
+type InMemoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*list.Element
+	order   *list.List
+	now     func() time.Time
+}
+
+type bucket struct {
+	key       string
+	tokens    float64
+	updatedAt time.Time
+}
+
+func NewInMemoryLimiter() *InMemoryLimiter {
+	return &InMemoryLimiter{
+		buckets: make(map[string]*list.Element),
+		order:   list.New(),
+		now:     time.Now,
+	}
+}
+
+func (l *InMemoryLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b := l.bucket(key, limit, now)
+
+	b.tokens = math.Min(limit.capacity(), b.tokens+now.Sub(b.updatedAt).Seconds()*limit.rate())
+	b.updatedAt = now
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	return newResult(limit, allowed, b.tokens), nil
+}
+
+// bucket returns the bucket of the key as the most recently used one, and creates it full
+// when it is missing, evicting the least recently used bucket when there are too many.
+func (l *InMemoryLimiter) bucket(key string, limit Limit, now time.Time) *bucket {
+	if element, ok := l.buckets[key]; ok {
+		l.order.MoveToFront(element)
+		return element.Value.(*bucket)
+	}
+
+	b := &bucket{key: key, tokens: limit.capacity(), updatedAt: now}
+	l.buckets[key] = l.order.PushFront(b)
+	if l.order.Len() > maxInMemoryBuckets {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.buckets, oldest.Value.(*bucket).key)
+	}
+	return b
+}
@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit is a token bucket that holds Burst tokens and refills Requests tokens every Period.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+func (l Limit) capacity() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return float64(l.Requests)
+}
+
+// Enabled reports if the limit is set, zero limits let every request through.
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// Result is the state of the bucket after taking a token.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is the time until the next token, zero when the request is allowed
+	RetryAfter time.Duration
+	// ResetAfter is the time until the bucket is full again
+	ResetAfter time.Duration
+}
+
+// Limiter takes a token of the key bucket.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// newResult builds the result from the tokens left in the bucket after the request.
+func newResult(limit Limit, allowed bool, tokens float64) Result {
+	result := Result{
+		Allowed:    allowed,
+		Limit:      int(limit.capacity()),
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: seconds((limit.capacity() - tokens) / limit.rate()),
+	}
+	if !allowed {
+		result.RetryAfter = seconds((1 - tokens) / limit.rate())
+	}
+	return result
+}
+
+func seconds(s float64) time.Duration {
+	if s <= 0 {
+		return 0
+	}
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+)
+
+const redisKeyPrefix = "timeline:rate_limit:"
+
+// takeTokenScript refills the bucket with the time elapsed since the last request and takes
+// a token when there is one, atomically so concurrent replicas do not race. The Redis clock
+// is used so the replicas clocks do not matter. Buckets expire once they would be full
+// again, a missing bucket starts full.
+var takeTokenScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated_at')
+local tokens = tonumber(bucket[1]) or capacity
+local updated_at = tonumber(bucket[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - updated_at) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+    tokens = tokens - 1
+    allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated_at', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisLimiter shares the buckets between every replica on Redis.
+type RedisLimiter struct {
+	client *redis.Client
+}
+
+func NewRedisLimiter(client *redis.Client) *RedisLimiter {
+	return &RedisLimiter{client: client}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	values, err := takeTokenScript.Run(ctx, l.client, []string{redisKeyPrefix + key}, limit.capacity(), limit.rate()).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("error taking rate limit token: %w", err)
+	}
+	if len(values) != 2 {
+		return Result{}, fmt.Errorf("error taking rate limit token: unexpected reply %v", values)
+	}
+
+	allowed, _ := values[0].(int64)
+	rawTokens, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(rawTokens, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("error parsing rate limit tokens: %w", err)
+	}
+
+	return newResult(limit, allowed == 1, tokens), nil
+}
@@ -0,0 +1,6 @@
+-- Rows inserted before this migration keep a NULL author_id, they are not
+-- removed when the follower unfollows the author until the author is set with
+-- `go run ./cmd/backfill -authors`.
+ALTER TABLE timelines ADD COLUMN IF NOT EXISTS author_id VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS timelines_user_id_author_id_idx ON timelines (user_id, author_id);
@@ -0,0 +1,2 @@
+-- Serves the timeline reads by date range and the new posts count.
+CREATE INDEX IF NOT EXISTS timelines_user_id_published_at_idx ON timelines (user_id, published_at DESC, post_id DESC);
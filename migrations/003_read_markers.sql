@@ -0,0 +1,11 @@
+-- Last post seen by each user on each device.
+CREATE TABLE IF NOT EXISTS read_markers (
+    user_id      VARCHAR(255) NOT NULL,
+    device_id    VARCHAR(255) NOT NULL,
+    post_id      VARCHAR(255) NOT NULL,
+    published_at TIMESTAMPTZ  NOT NULL,
+    updated_at   TIMESTAMPTZ  NOT NULL,
+    PRIMARY KEY (user_id, device_id)
+);
+
+CREATE INDEX IF NOT EXISTS read_markers_user_id_published_at_idx ON read_markers (user_id, published_at DESC, post_id DESC);
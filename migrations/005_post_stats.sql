@@ -0,0 +1,24 @@
+-- Engagements counted on the post stats, kept so a repeated event is only counted once.
+-- Engagements taken back are kept as tombstones with the time of the undo in removed_at,
+-- so an add delivered after its undo is not counted. created_at is the time of the add.
+CREATE TABLE IF NOT EXISTS post_engagements (
+    post_id       VARCHAR(255) NOT NULL,
+    kind          VARCHAR(16)  NOT NULL,
+    engagement_id VARCHAR(255) NOT NULL,
+    user_id       VARCHAR(255) NOT NULL,
+    created_at    TIMESTAMPTZ  NOT NULL,
+    removed_at    TIMESTAMPTZ,
+    PRIMARY KEY (post_id, kind, engagement_id)
+);
+
+-- Like, repost and reply counters of each post.
+CREATE TABLE IF NOT EXISTS post_stats (
+    post_id    VARCHAR(255) PRIMARY KEY,
+    likes      BIGINT       NOT NULL DEFAULT 0,
+    reposts    BIGINT       NOT NULL DEFAULT 0,
+    replies    BIGINT       NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ  NOT NULL
+);
+
+-- Serves the engagements of a reader with each author, the author affinity of the ranking.
+CREATE INDEX IF NOT EXISTS post_engagements_user_id_idx ON post_engagements (user_id);
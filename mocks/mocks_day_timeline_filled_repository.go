@@ -82,6 +82,24 @@ func (_m *DayUserTimelineFilledRepository) RemovePost(ctx context.Context, userI
 	return r0
 }
 
+// RemovePosts provides a mock function with given fields: ctx, userID, _a2
+func (_m *DayUserTimelineFilledRepository) RemovePosts(ctx context.Context, userID string, _a2 []posts.Post) error {
+	ret := _m.Called(ctx, userID, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemovePosts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []posts.Post) error); ok {
+		r0 = rf(ctx, userID, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdatePosts provides a mock function with given fields: ctx, userID, post
 func (_m *DayUserTimelineFilledRepository) UpdatePosts(ctx context.Context, userID string, post *posts.Post) error {
 	ret := _m.Called(ctx, userID, post)
@@ -7,6 +7,8 @@ import (
 	posts "uala-timeline-service/internal/domain/posts"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // PostRepository is an autogenerated mock type for the PostRepository type
@@ -14,6 +16,36 @@ type PostRepository struct {
 	mock.Mock
 }
 
+// GetAuthorPosts provides a mock function with given fields: ctx, authorID, since
+func (_m *PostRepository) GetAuthorPosts(ctx context.Context, authorID string, since time.Time) ([]posts.Post, error) {
+	ret := _m.Called(ctx, authorID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthorPosts")
+	}
+
+	var r0 []posts.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]posts.Post, error)); ok {
+		return rf(ctx, authorID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []posts.Post); ok {
+		r0 = rf(ctx, authorID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]posts.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, authorID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPostById provides a mock function with given fields: ctx, id
 func (_m *PostRepository) GetPostById(ctx context.Context, id string) (*posts.Post, error) {
 	ret := _m.Called(ctx, id)
@@ -45,23 +77,23 @@ func (_m *PostRepository) GetPostById(ctx context.Context, id string) (*posts.Po
 }
 
 // MGetPosts provides a mock function with given fields: ctx, postIDs
-func (_m *PostRepository) MGetPosts(ctx context.Context, postIDs []string) ([]posts.Post, error) {
+func (_m *PostRepository) MGetPosts(ctx context.Context, postIDs []string) (*posts.MGetPostsResult, error) {
 	ret := _m.Called(ctx, postIDs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MGetPosts")
 	}
 
-	var r0 []posts.Post
+	var r0 *posts.MGetPostsResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]posts.Post, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (*posts.MGetPostsResult, error)); ok {
 		return rf(ctx, postIDs)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string) []posts.Post); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, []string) *posts.MGetPostsResult); ok {
 		r0 = rf(ctx, postIDs)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]posts.Post)
+			r0 = ret.Get(0).(*posts.MGetPostsResult)
 		}
 	}
 
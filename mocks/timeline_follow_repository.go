@@ -32,6 +32,96 @@ func (_m *TimelineRepository) AddPostToUserTimeline(ctx context.Context, userID
 	return r0
 }
 
+// GetAuthorPostsFromTimeline provides a mock function with given fields: ctx, userID, authorID
+func (_m *TimelineRepository) GetAuthorPostsFromTimeline(ctx context.Context, userID string, authorID string) ([]timeline.PostTimeline, error) {
+	ret := _m.Called(ctx, userID, authorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthorPostsFromTimeline")
+	}
+
+	var r0 []timeline.PostTimeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]timeline.PostTimeline, error)); ok {
+		return rf(ctx, userID, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []timeline.PostTimeline); ok {
+		r0 = rf(ctx, userID, authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]timeline.PostTimeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNewPosts provides a mock function with given fields: ctx, userID, filter
+func (_m *TimelineRepository) GetNewPosts(ctx context.Context, userID string, filter timeline.NewPostsFilter) ([]timeline.PostTimeline, error) {
+	ret := _m.Called(ctx, userID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNewPosts")
+	}
+
+	var r0 []timeline.PostTimeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, timeline.NewPostsFilter) ([]timeline.PostTimeline, error)); ok {
+		return rf(ctx, userID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, timeline.NewPostsFilter) []timeline.PostTimeline); ok {
+		r0 = rf(ctx, userID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]timeline.PostTimeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, timeline.NewPostsFilter) error); ok {
+		r1 = rf(ctx, userID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPostIDsWithoutAuthor provides a mock function with given fields: ctx, afterPostID, limit
+func (_m *TimelineRepository) GetPostIDsWithoutAuthor(ctx context.Context, afterPostID string, limit int) ([]string, error) {
+	ret := _m.Called(ctx, afterPostID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostIDsWithoutAuthor")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]string, error)); ok {
+		return rf(ctx, afterPostID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []string); ok {
+		r0 = rf(ctx, afterPostID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, afterPostID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserPostTimeline provides a mock function with given fields: ctx, userID, postId
 func (_m *TimelineRepository) GetUserPostTimeline(ctx context.Context, userID string, postId string) (*timeline.UserTimeline, error) {
 	ret := _m.Called(ctx, userID, postId)
@@ -110,6 +200,42 @@ func (_m *TimelineRepository) RemovePostFromTimeline(ctx context.Context, userID
 	return r0
 }
 
+// RemovePostsFromTimeline provides a mock function with given fields: ctx, userID, postIDs
+func (_m *TimelineRepository) RemovePostsFromTimeline(ctx context.Context, userID string, postIDs []string) error {
+	ret := _m.Called(ctx, userID, postIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemovePostsFromTimeline")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, userID, postIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetPostAuthor provides a mock function with given fields: ctx, postID, authorID
+func (_m *TimelineRepository) SetPostAuthor(ctx context.Context, postID string, authorID string) error {
+	ret := _m.Called(ctx, postID, authorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPostAuthor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, postID, authorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTimelineRepository creates a new instance of TimelineRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTimelineRepository(t interface {